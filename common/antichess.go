@@ -0,0 +1,108 @@
+package common
+
+// generateAntichess generates the moves of an antichess position.
+// Captures are compulsory, kings are ordinary pieces and a pawn may promote to a king.
+// There is no check and no castling, so every generated move is legal.
+func (p *Position) generateAntichess(ml []OrderedMove) []OrderedMove {
+	var count = p.antichessMoves(ml, true)
+	if count == 0 {
+		count = p.antichessMoves(ml, false)
+	}
+	return ml[:count]
+}
+
+// generateAntichessCaptures returns the moves GenerateCaptures returns in chess:
+// captures without underpromotion and queen promotions
+func (p *Position) generateAntichessCaptures(ml []OrderedMove) []OrderedMove {
+	var count = 0
+	for _, om := range p.generateAntichess(ml) {
+		var promotion = om.Move.Promotion()
+		if promotion == Queen || promotion == Empty && om.Move.CapturedPiece() != Empty {
+			ml[count] = om
+			count++
+		}
+	}
+	return ml[:count]
+}
+
+// antichessMoves adds the captures or the quiet moves of the side to move
+func (p *Position) antichessMoves(ml []OrderedMove, captures bool) int {
+	var count = 0
+	var ownPieces = p.PiecesByColor(p.WhiteMove)
+	var allPieces = p.White | p.Black
+	var target = ^allPieces
+	if captures {
+		target = p.PiecesByColor(!p.WhiteMove)
+	}
+
+	var ownPawns = p.Pawns & ownPieces
+	if captures && p.EpSquare != SquareNone {
+		for fromBB := PawnAttacks(p.EpSquare, !p.WhiteMove) & ownPawns; fromBB != 0; fromBB &= fromBB - 1 {
+			ml[count].Move = makeMove(FirstOne(fromBB), p.EpSquare, Pawn, Pawn)
+			count++
+		}
+	}
+
+	var forward = let(p.WhiteMove, 8, -8)
+	var doublePushRank = let(p.WhiteMove, Rank2, Rank7)
+	var lastRank = let(p.WhiteMove, Rank8, Rank1)
+	for fromBB := ownPawns; fromBB != 0; fromBB &= fromBB - 1 {
+		var from = FirstOne(fromBB)
+		var toBB uint64
+		if captures {
+			toBB = PawnAttacks(from, p.WhiteMove) & target
+		} else if (SquareMask[from+forward] & target) != 0 {
+			toBB = SquareMask[from+forward]
+			if Rank(from) == doublePushRank {
+				toBB |= SquareMask[from+2*forward] & target
+			}
+		}
+		for ; toBB != 0; toBB &= toBB - 1 {
+			var to = FirstOne(toBB)
+			var move = makeMove(from, to, Pawn, p.WhatPiece(to))
+			if Rank(to) == lastRank {
+				count += addPromotions(ml[count:], move)
+				ml[count].Move = move ^ Move(King<<18)
+				count++
+			} else {
+				ml[count].Move = move
+				count++
+			}
+		}
+	}
+
+	for fromBB := ownPieces &^ p.Pawns; fromBB != 0; fromBB &= fromBB - 1 {
+		var from = FirstOne(fromBB)
+		var piece = p.WhatPiece(from)
+		var attacks uint64
+		switch piece {
+		case Knight:
+			attacks = KnightAttacks[from]
+		case Bishop:
+			attacks = BishopAttacks(from, allPieces)
+		case Rook:
+			attacks = RookAttacks(from, allPieces)
+		case Queen:
+			attacks = QueenAttacks(from, allPieces)
+		case King:
+			attacks = KingAttacks[from]
+		}
+		for toBB := attacks & target; toBB != 0; toBB &= toBB - 1 {
+			var to = FirstOne(toBB)
+			ml[count].Move = makeMove(from, to, piece, p.WhatPiece(to))
+			count++
+		}
+	}
+	return count
+}
+
+// isAntichessMove reports whether move is one of the moves of the position
+func (p *Position) isAntichessMove(move Move) bool {
+	var buffer [MaxMoves]OrderedMove
+	for _, om := range p.generateAntichess(buffer[:]) {
+		if om.Move == move {
+			return true
+		}
+	}
+	return false
+}
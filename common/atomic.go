@@ -0,0 +1,58 @@
+package common
+
+// explode removes the capturing piece and the pieces next to the capture square except pawns
+func (p *Position) explode(to int) {
+	var castleRights = p.CastleRights
+	for bb := (KingAttacks[to]&^p.Pawns | SquareMask[to]) & (p.White | p.Black); bb != 0; bb &= bb - 1 {
+		var sq = FirstOne(bb)
+		var piece, side = p.GetPieceTypeAndSide(sq)
+		xorPiece(p, piece, side, sq)
+		p.CastleRights &= castleMask[sq]
+	}
+	p.Key ^= castlingKey[p.CastleRights^castleRights]
+}
+
+// atomicCheckers returns the pieces giving check to the king of side.
+// Kings can not capture, so a king is not in check next to the enemy king.
+func (p *Position) atomicCheckers(side bool) uint64 {
+	var king = p.Kings & p.PiecesByColor(side)
+	var enemyKing = p.Kings & p.PiecesByColor(!side)
+	if king == 0 || enemyKing == 0 || (KingAttacks[FirstOne(king)]&enemyKing) != 0 {
+		return 0
+	}
+	return p.Attackers(FirstOne(king), !side) &^ p.Kings
+}
+
+// isAtomicKingSafe reports whether the king of side survived and is not in check,
+// exploding the enemy king wins even if the own king is in check
+func (p *Position) isAtomicKingSafe(side bool) bool {
+	if (p.Kings & p.PiecesByColor(side)) == 0 {
+		return false
+	}
+	return (p.Kings&p.PiecesByColor(!side)) == 0 || p.atomicCheckers(side) == 0
+}
+
+// isCheckedSquare reports whether a king on sq would be in check by side,
+// castling uses it for the squares the king passes
+func (p *Position) isCheckedSquare(sq int, side bool) bool {
+	if p.Variant != VariantAtomic {
+		return p.IsAttackedBySide(sq, side)
+	}
+	if (KingAttacks[sq] & p.Kings & p.PiecesByColor(side)) != 0 {
+		return false
+	}
+	return (p.Attackers(sq, side) &^ p.Kings) != 0
+}
+
+// generateAtomicLegal filters the moves of GenerateMoves by making them
+func (p *Position) generateAtomicLegal(ml []OrderedMove) []OrderedMove {
+	var count = 0
+	var child Position
+	for _, om := range p.GenerateMoves(ml) {
+		if p.MakeMove(om.Move, &child) {
+			ml[count] = om
+			count++
+		}
+	}
+	return ml[:count]
+}
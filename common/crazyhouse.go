@@ -0,0 +1,129 @@
+package common
+
+import (
+	"strings"
+)
+
+// generateDrops adds the drops of the pieces in the pocket of the side to move on target
+func (p *Position) generateDrops(ml []OrderedMove, target uint64) int {
+	var count = 0
+	var pocket = &p.Pockets[sideIndex(p.WhiteMove)]
+	for piece := Pawn; piece < King; piece++ {
+		if pocket[piece] == 0 {
+			continue
+		}
+		var toBB = target
+		if piece == Pawn {
+			toBB &^= Rank1Mask | Rank8Mask
+		}
+		for ; toBB != 0; toBB &= toBB - 1 {
+			ml[count].Move = makeDrop(piece, FirstOne(toBB))
+			count++
+		}
+	}
+	return count
+}
+
+// isPseudoLegalDrop is IsPseudoLegal for drops
+func (p *Position) isPseudoLegalDrop(move Move) bool {
+	var piece = move.MovingPiece()
+	var to = move.To()
+	return p.Variant == VariantCrazyhouse &&
+		move.CapturedPiece() == Empty && move.Promotion() == Empty &&
+		piece >= Pawn && piece < King &&
+		p.Pockets[sideIndex(p.WhiteMove)][piece] != 0 &&
+		(SquareMask[to]&(p.White|p.Black)) == 0 &&
+		!(piece == Pawn && (Rank(to) == Rank1 || Rank(to) == Rank8))
+}
+
+// updatePockets moves a captured piece to the pocket of side and follows the promoted pieces,
+// the pieces on the board have already been moved
+func (p *Position) updatePockets(move Move, side bool) {
+	var from = move.From()
+	var to = move.To()
+	var index = sideIndex(side)
+	if move.IsDrop() {
+		p.setPocket(index, move.MovingPiece(), int(p.Pockets[index][move.MovingPiece()])-1)
+		return
+	}
+	if captured := move.CapturedPiece(); captured != Empty {
+		if (p.Promoted & SquareMask[to]) != 0 {
+			captured = Pawn
+			p.Promoted &^= SquareMask[to]
+		}
+		p.setPocket(index, captured, int(p.Pockets[index][captured])+1)
+	}
+	if (p.Promoted & SquareMask[from]) != 0 {
+		p.Promoted ^= SquareMask[from] | SquareMask[to]
+	}
+	if move.Promotion() != Empty {
+		p.Promoted |= SquareMask[to]
+	}
+}
+
+func (p *Position) setPocket(index, piece, count int) {
+	p.Key ^= pocketKey[index][piece][p.Pockets[index][piece]] ^ pocketKey[index][piece][count]
+	p.Pockets[index][piece] = uint8(count)
+}
+
+// parseCrazyhousePlacement splits the pocket in brackets from the piece placement
+// and removes the ~ marks of promoted pieces
+func parseCrazyhousePlacement(s string) (placement string, pockets [2][King]uint8, promoted uint64, err error) {
+	placement = s
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		if !strings.HasSuffix(s, "]") {
+			return "", pockets, 0, fenError(FENPocket, s[i:], "expected ] at the end")
+		}
+		placement = s[:i]
+		for _, ch := range s[i+1 : len(s)-1] {
+			var piece = parsePiece(ch)
+			if piece.Type == Empty || piece.Type == King {
+				return "", pockets, 0, fenError(FENPocket, s[i:], "invalid character %q", ch)
+			}
+			if pockets[sideIndex(piece.Side)][piece.Type] == 32 {
+				return "", pockets, 0, fenError(FENPocket, s[i:], "too many pieces")
+			}
+			pockets[sideIndex(piece.Side)][piece.Type]++
+		}
+	}
+
+	var sb strings.Builder
+	var file, rank = 0, Rank8
+	var lastPiece = false
+	for _, ch := range placement {
+		switch {
+		case ch == '~':
+			if !lastPiece || file > 8 || rank < Rank1 {
+				return "", pockets, 0, fenError(FENPlacement, s, "~ does not follow a piece")
+			}
+			promoted |= SquareMask[MakeSquare(file-1, rank)]
+			lastPiece = false
+			continue
+		case ch == '/':
+			file = 0
+			rank--
+		case ch >= '1' && ch <= '8':
+			file += int(ch - '0')
+		default:
+			file++
+		}
+		lastPiece = ch != '/' && (ch < '1' || ch > '8')
+		sb.WriteRune(ch)
+	}
+	return sb.String(), pockets, promoted, nil
+}
+
+// pocketString returns the pocket of the FEN with the white pieces first
+func (p *Position) pocketString() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for _, side := range [2]bool{true, false} {
+		for piece := Queen; piece >= Pawn; piece-- {
+			for i := 0; i < int(p.Pockets[sideIndex(side)][piece]); i++ {
+				sb.WriteString(pieceToChar(piece, side))
+			}
+		}
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
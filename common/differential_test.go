@@ -0,0 +1,43 @@
+package common
+
+import (
+	"testing"
+)
+
+// TestRandomGames checks every legal move of random game positions:
+// the incremental key, SAN round trip and the mirrored position.
+func TestRandomGames(t *testing.T) {
+	var games = 100
+	if testing.Short() {
+		games = 20
+	}
+	var corpus = fenCorpus(games, 200)
+	var child Position
+	for i := range corpus {
+		var p = &corpus[i]
+		var ml = p.GenerateLegalMoves()
+		for _, move := range ml {
+			p.MakeMove(move, &child)
+			if child.Key != child.computeKey() {
+				t.Fatalf("%v %v: key %x, expected %x", p, move, child.Key, child.computeKey())
+			}
+			var san = moveToSAN(p, ml, move)
+			if parsed := ParseMoveSAN(p, san); parsed != move {
+				t.Fatalf("%v: %v as %v parsed to %v", p, move, san, parsed)
+			}
+		}
+
+		var mirror = MirrorPosition(p)
+		if mirror.Key != mirror.computeKey() {
+			t.Fatalf("%v: mirror key", p)
+		}
+		var mirrorBack = MirrorPosition(&mirror)
+		mirrorBack.LastMove = p.LastMove
+		if mirrorBack != *p {
+			t.Fatalf("%v: mirrored twice %v", p, &mirrorBack)
+		}
+		if n := len(mirror.GenerateLegalMoves()); n != len(ml) {
+			t.Fatalf("%v: mirror %v has %v moves, expected %v", p, &mirror, n, len(ml))
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Opening is an ECO classification
+type Opening struct {
+	ECO       string
+	Name      string
+	Variation string
+}
+
+func (o Opening) String() string {
+	if o.Variation == "" {
+		return o.ECO + " " + o.Name
+	}
+	return o.ECO + " " + o.Name + ": " + o.Variation
+}
+
+type ecoLine struct {
+	eco       string
+	name      string
+	variation string
+	moves     string
+}
+
+var (
+	ecoOnce  sync.Once
+	ecoIndex map[uint64]Opening
+)
+
+// ecoPositions builds the index from position key to opening on first use,
+// later lines overwrite earlier ones reaching the same position.
+func ecoPositions() map[uint64]Opening {
+	ecoOnce.Do(func() {
+		ecoIndex = make(map[uint64]Opening, len(ecoTable))
+		for _, line := range ecoTable {
+			var positions, err = ParseMoves(initialPosition(), line.moves)
+			if err != nil {
+				panic(fmt.Errorf("eco %v %v: %v", line.eco, line.name, err))
+			}
+			var last = &positions[len(positions)-1]
+			ecoIndex[repetitionKey(last)] = Opening{line.eco, line.name, line.variation}
+		}
+	})
+	return ecoIndex
+}
+
+func initialPosition() Position {
+	var p, err = NewPositionFromFEN(InitialPositionFen)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ClassifyOpening returns the deepest known opening position of the game,
+// positions are matched by key so transpositions are recognized.
+// The table holds the main lines of all ECO codes, deeper sidelines
+// keep the code of the last main line position reached.
+func ClassifyOpening(positions []Position) (Opening, bool) {
+	var index = ecoPositions()
+	for i := len(positions) - 1; i >= 0; i-- {
+		if opening, ok := index[repetitionKey(&positions[i])]; ok {
+			return opening, true
+		}
+	}
+	return Opening{}, false
+}
+
+// ParseMoves plays moves in SAN or LAN separated by spaces from the start position,
+// the result contains the start position and the positions after every move.
+func ParseMoves(start Position, moves string) ([]Position, error) {
+	var positions = []Position{start}
+	for _, token := range strings.Fields(moves) {
+		var child, err = playMoveText(&positions[len(positions)-1], token)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, child)
+	}
+	return positions, nil
+}
+
+// playMoveText accepts SAN and falls back to LAN
+func playMoveText(p *Position, s string) (Position, error) {
+	var move, err = ParseSAN(p, s)
+	if err != nil {
+		if child, ok := p.MakeMoveLAN(s); ok {
+			return child, nil
+		}
+		return Position{}, err
+	}
+	var child Position
+	p.MakeMove(move, &child)
+	return child, nil
+}
@@ -0,0 +1,559 @@
+package common
+
+// ecoTable lists openings by their moves from the initial position,
+// every code from A00 to E99 has at least one line.
+// A game gets the opening of its last position found here.
+var ecoTable = []ecoLine{
+	{"A00", "Polish Opening", "", "b4"},
+	{"A00", "Grob Opening", "", "g4"},
+	{"A00", "Van't Kruijs Opening", "", "e3"},
+	{"A00", "Mieses Opening", "", "d3"},
+	{"A00", "Saragossa Opening", "", "c3"},
+	{"A00", "Anderssen's Opening", "", "a3"},
+	{"A00", "Clemenz Opening", "", "h3"},
+	{"A00", "Ware Opening", "", "a4"},
+	{"A00", "Kadas Opening", "", "h4"},
+	{"A00", "Amar Opening", "", "Nh3"},
+	{"A00", "Hungarian Opening", "", "g3"},
+	{"A00", "Barnes Opening", "", "f3"},
+	{"A00", "Barnes Opening", "Fool's Mate", "f3 e5 g4 Qh4#"},
+	{"A00", "Van Geet Opening", "", "Nc3"},
+	{"A01", "Nimzo-Larsen Attack", "", "b3"},
+	{"A02", "Bird Opening", "", "f4"},
+	{"A02", "Bird Opening", "From's Gambit", "f4 e5"},
+	{"A03", "Bird Opening", "Dutch Variation", "f4 d5"},
+	{"A04", "Zukertort Opening", "", "Nf3"},
+	{"A04", "Zukertort Opening", "Sicilian Invitation", "Nf3 c5"},
+	{"A05", "Zukertort Opening", "", "Nf3 Nf6"},
+	{"A06", "Zukertort Opening", "", "Nf3 d5"},
+	{"A07", "King's Indian Attack", "", "Nf3 d5 g3"},
+	{"A08", "King's Indian Attack", "", "Nf3 d5 g3 c5 Bg2"},
+	{"A09", "Reti Opening", "", "Nf3 d5 c4"},
+	{"A10", "English Opening", "", "c4"},
+	{"A11", "English Opening", "Caro-Kann Defensive System", "c4 c6"},
+	{"A12", "English Opening", "Caro-Kann Defensive System, Bogoljubov Variation", "c4 c6 Nf3 d5 b3"},
+	{"A13", "English Opening", "Agincourt Defense", "c4 e6"},
+	{"A14", "English Opening", "Agincourt Defense, Neo-Catalan Declined", "c4 e6 Nf3 d5 g3 Nf6 Bg2 Be7 O-O"},
+	{"A15", "English Opening", "Anglo-Indian Defense", "c4 Nf6"},
+	{"A16", "English Opening", "Anglo-Indian Defense", "c4 Nf6 Nc3"},
+	{"A17", "English Opening", "Anglo-Indian Defense, Hedgehog System", "c4 Nf6 Nc3 e6"},
+	{"A18", "English Opening", "Mikenas-Carls Variation", "c4 Nf6 Nc3 e6 e4"},
+	{"A19", "English Opening", "Mikenas-Carls Variation, Sicilian Variation", "c4 Nf6 Nc3 e6 e4 c5"},
+	{"A20", "English Opening", "King's English Variation", "c4 e5"},
+	{"A21", "English Opening", "King's English Variation, Reversed Sicilian", "c4 e5 Nc3"},
+	{"A22", "English Opening", "King's English Variation, Two Knights Variation", "c4 e5 Nc3 Nf6"},
+	{"A23", "English Opening", "King's English Variation, Two Knights Variation, Keres Variation", "c4 e5 Nc3 Nf6 g3 c6"},
+	{"A24", "English Opening", "King's English Variation, Two Knights Variation, Fianchetto Line", "c4 e5 Nc3 Nf6 g3 g6"},
+	{"A25", "English Opening", "King's English Variation, Reversed Closed Sicilian", "c4 e5 Nc3 Nc6"},
+	{"A26", "English Opening", "King's English Variation, Botvinnik System", "c4 e5 Nc3 Nc6 g3 g6 Bg2 Bg7 d3 d6"},
+	{"A27", "English Opening", "King's English Variation, Three Knights System", "c4 e5 Nc3 Nc6 Nf3"},
+	{"A28", "English Opening", "King's English Variation, Four Knights Variation", "c4 e5 Nc3 Nc6 Nf3 Nf6"},
+	{"A29", "English Opening", "King's English Variation, Four Knights Variation, Fianchetto Line", "c4 e5 Nc3 Nc6 Nf3 Nf6 g3"},
+	{"A30", "English Opening", "Symmetrical Variation", "c4 c5"},
+	{"A31", "English Opening", "Symmetrical Variation, Anti-Benoni Variation", "c4 c5 Nf3 Nf6 d4"},
+	{"A32", "English Opening", "Symmetrical Variation, Anti-Benoni Variation, Spielmann Defense", "c4 c5 Nf3 Nf6 d4 cxd4 Nxd4 e6"},
+	{"A33", "English Opening", "Symmetrical Variation, Anti-Benoni Variation, Geller Variation", "c4 c5 Nf3 Nf6 d4 cxd4 Nxd4 e6 Nc3 Nc6"},
+	{"A34", "English Opening", "Symmetrical Variation, Normal Variation", "c4 c5 Nc3"},
+	{"A35", "English Opening", "Symmetrical Variation, Two Knights Variation", "c4 c5 Nc3 Nc6"},
+	{"A36", "English Opening", "Symmetrical Variation, Fianchetto Variation", "c4 c5 Nc3 Nc6 g3"},
+	{"A37", "English Opening", "Symmetrical Variation, Two Knights Line", "c4 c5 Nc3 Nc6 g3 g6 Bg2 Bg7 Nf3"},
+	{"A38", "English Opening", "Symmetrical Variation, Full Symmetry Line", "c4 c5 Nc3 Nc6 g3 g6 Bg2 Bg7 Nf3 Nf6"},
+	{"A39", "English Opening", "Symmetrical Variation, Mecking Variation", "c4 c5 Nc3 Nc6 g3 g6 Bg2 Bg7 Nf3 Nf6 O-O O-O d4"},
+	{"A40", "Queen's Pawn Game", "", "d4"},
+	{"A40", "Englund Gambit", "", "d4 e5"},
+	{"A40", "Modern Defense", "", "d4 g6"},
+	{"A41", "Queen's Pawn Game", "", "d4 d6"},
+	{"A42", "Modern Defense", "Averbakh System", "d4 d6 c4 g6 Nc3 Bg7 e4"},
+	{"A43", "Benoni Defense", "Old Benoni", "d4 c5"},
+	{"A44", "Benoni Defense", "Old Benoni, Czech Variation", "d4 c5 d5 e5"},
+	{"A45", "Indian Defense", "", "d4 Nf6"},
+	{"A45", "Trompowsky Attack", "", "d4 Nf6 Bg5"},
+	{"A46", "Indian Defense", "Knights Variation", "d4 Nf6 Nf3"},
+	{"A47", "Queen's Indian Defense", "", "d4 Nf6 Nf3 b6"},
+	{"A48", "Indian Defense", "East Indian Defense", "d4 Nf6 Nf3 g6"},
+	{"A49", "Indian Defense", "Przepiorka Variation", "d4 Nf6 Nf3 g6 g3"},
+	{"A50", "Indian Defense", "Normal Variation", "d4 Nf6 c4"},
+	{"A51", "Budapest Defense", "", "d4 Nf6 c4 e5"},
+	{"A52", "Budapest Defense", "Adler Variation", "d4 Nf6 c4 e5 dxe5 Ng4"},
+	{"A53", "Old Indian Defense", "", "d4 Nf6 c4 d6"},
+	{"A54", "Old Indian Defense", "Ukrainian Variation", "d4 Nf6 c4 d6 Nc3 e5"},
+	{"A55", "Old Indian Defense", "Normal Variation", "d4 Nf6 c4 d6 Nc3 e5 Nf3 Nbd7 e4"},
+	{"A56", "Benoni Defense", "", "d4 Nf6 c4 c5"},
+	{"A57", "Benko Gambit", "", "d4 Nf6 c4 c5 d5 b5"},
+	{"A58", "Benko Gambit Accepted", "Fully Accepted Variation", "d4 Nf6 c4 c5 d5 b5 cxb5 a6 bxa6"},
+	{"A59", "Benko Gambit Accepted", "King's Pawn Line", "d4 Nf6 c4 c5 d5 b5 cxb5 a6 bxa6 Bxa6 Nc3 d6 e4"},
+	{"A60", "Benoni Defense", "Modern Variation", "d4 Nf6 c4 c5 d5 e6"},
+	{"A61", "Benoni Defense", "", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 Nf3 g6"},
+	{"A62", "Benoni Defense", "Fianchetto Variation", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 Nf3 g6 g3 Bg7 Bg2 O-O O-O"},
+	{"A63", "Benoni Defense", "Fianchetto Variation, Hastings Defense", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 Nf3 g6 g3 Bg7 Bg2 O-O O-O Nbd7"},
+	{"A64", "Benoni Defense", "Fianchetto Variation, Hastings Defense, Main Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 Nf3 g6 g3 Bg7 Bg2 O-O O-O Nbd7 Nd2 a6 a4 Re8"},
+	{"A65", "Benoni Defense", "King's Pawn Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4"},
+	{"A66", "Benoni Defense", "Pawn Storm Variation", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 f4"},
+	{"A67", "Benoni Defense", "Taimanov Variation", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 f4 Bg7 Bb5+"},
+	{"A68", "Benoni Defense", "Four Pawns Attack", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 f4 Bg7 Nf3 O-O"},
+	{"A69", "Benoni Defense", "Four Pawns Attack, Main Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 f4 Bg7 Nf3 O-O Be2 Re8"},
+	{"A70", "Benoni Defense", "Classical Variation", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3"},
+	{"A71", "Benoni Defense", "Classical Variation, Averbakh-Grivas Attack", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Bg5"},
+	{"A72", "Benoni Defense", "Classical Variation", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O"},
+	{"A73", "Benoni Defense", "Classical Variation, Main Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O"},
+	{"A74", "Benoni Defense", "Classical Variation, Full Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O a6 a4"},
+	{"A75", "Benoni Defense", "Classical Variation, Argentine Counterattack", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O a6 a4 Bg4"},
+	{"A76", "Benoni Defense", "Classical Variation, Czerniak Defense", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O Re8"},
+	{"A77", "Benoni Defense", "Classical Variation, Czerniak Defense, Tal Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O Re8 Nd2"},
+	{"A78", "Benoni Defense", "Classical Variation, Czerniak Defense, Tal Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O Re8 Nd2 Na6"},
+	{"A79", "Benoni Defense", "Classical Variation, Czerniak Defense, Tal Line", "d4 Nf6 c4 c5 d5 e6 Nc3 exd5 cxd5 d6 e4 g6 Nf3 Bg7 Be2 O-O O-O Re8 Nd2 Na6 f3"},
+	{"A80", "Dutch Defense", "", "d4 f5"},
+	{"A81", "Dutch Defense", "Fianchetto Attack", "d4 f5 g3"},
+	{"A82", "Dutch Defense", "Staunton Gambit", "d4 f5 e4"},
+	{"A83", "Dutch Defense", "Staunton Gambit, Staunton's Line", "d4 f5 e4 fxe4 Nc3 Nf6 Bg5"},
+	{"A84", "Dutch Defense", "", "d4 f5 c4"},
+	{"A85", "Dutch Defense", "Queen's Knight Variation", "d4 f5 c4 Nf6 Nc3"},
+	{"A86", "Dutch Defense", "Fianchetto Variation", "d4 f5 c4 Nf6 g3"},
+	{"A87", "Dutch Defense", "Leningrad Variation", "d4 f5 c4 Nf6 g3 g6 Bg2 Bg7 Nf3"},
+	{"A88", "Dutch Defense", "Leningrad Variation, Warsaw Variation", "d4 f5 c4 Nf6 g3 g6 Bg2 Bg7 Nf3 O-O O-O d6 Nc3 c6"},
+	{"A89", "Dutch Defense", "Leningrad Variation, Matulovic Variation", "d4 f5 c4 Nf6 g3 g6 Bg2 Bg7 Nf3 O-O O-O d6 Nc3 Nc6"},
+	{"A90", "Dutch Defense", "Classical Variation", "d4 f5 c4 Nf6 g3 e6 Bg2"},
+	{"A91", "Dutch Defense", "Classical Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7"},
+	{"A92", "Dutch Defense", "Classical Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O"},
+	{"A93", "Dutch Defense", "Stonewall Variation, Botvinnik Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d5 b3"},
+	{"A94", "Dutch Defense", "Stonewall Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d5 b3 c6 Ba3"},
+	{"A95", "Dutch Defense", "Stonewall Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d5 Nc3 c6"},
+	{"A96", "Dutch Defense", "Classical Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d6"},
+	{"A97", "Dutch Defense", "Ilyin-Zhenevsky Variation", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d6 Nc3 Qe8"},
+	{"A98", "Dutch Defense", "Ilyin-Zhenevsky Variation, Alatortsev-Lisitsyn Line", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d6 Nc3 Qe8 Qc2"},
+	{"A99", "Dutch Defense", "Ilyin-Zhenevsky Variation, Modern Main Line", "d4 f5 c4 Nf6 g3 e6 Bg2 Be7 Nf3 O-O O-O d6 Nc3 Qe8 b3"},
+
+	{"B00", "King's Pawn Game", "", "e4"},
+	{"B00", "Nimzowitsch Defense", "", "e4 Nc6"},
+	{"B00", "Owen Defense", "", "e4 b6"},
+	{"B00", "St. George Defense", "", "e4 a6"},
+	{"B01", "Scandinavian Defense", "", "e4 d5"},
+	{"B01", "Scandinavian Defense", "Mieses-Kotroc Variation", "e4 d5 exd5 Qxd5"},
+	{"B01", "Scandinavian Defense", "Main Line", "e4 d5 exd5 Qxd5 Nc3 Qa5"},
+	{"B01", "Scandinavian Defense", "Modern Variation", "e4 d5 exd5 Nf6"},
+	{"B02", "Alekhine Defense", "", "e4 Nf6"},
+	{"B03", "Alekhine Defense", "", "e4 Nf6 e5 Nd5 d4"},
+	{"B03", "Alekhine Defense", "Four Pawns Attack", "e4 Nf6 e5 Nd5 d4 d6 c4 Nb6 f4"},
+	{"B04", "Alekhine Defense", "Modern Variation", "e4 Nf6 e5 Nd5 d4 d6 Nf3"},
+	{"B05", "Alekhine Defense", "Modern Variation, Main Line", "e4 Nf6 e5 Nd5 d4 d6 Nf3 Bg4"},
+	{"B06", "Modern Defense", "", "e4 g6"},
+	{"B07", "Pirc Defense", "", "e4 d6 d4 Nf6"},
+	{"B08", "Pirc Defense", "Classical Variation", "e4 d6 d4 Nf6 Nc3 g6 Nf3"},
+	{"B09", "Pirc Defense", "Austrian Attack", "e4 d6 d4 Nf6 Nc3 g6 f4"},
+	{"B10", "Caro-Kann Defense", "", "e4 c6"},
+	{"B11", "Caro-Kann Defense", "Two Knights Attack, Mindeno Variation", "e4 c6 Nc3 d5 Nf3 Bg4"},
+	{"B12", "Caro-Kann Defense", "Advance Variation", "e4 c6 d4 d5 e5"},
+	{"B13", "Caro-Kann Defense", "Exchange Variation", "e4 c6 d4 d5 exd5 cxd5"},
+	{"B13", "Caro-Kann Defense", "Panov Attack", "e4 c6 d4 d5 exd5 cxd5 c4"},
+	{"B14", "Caro-Kann Defense", "Panov Attack, Main Line", "e4 c6 d4 d5 exd5 cxd5 c4 Nf6 Nc3 e6"},
+	{"B15", "Caro-Kann Defense", "", "e4 c6 d4 d5 Nc3"},
+	{"B16", "Caro-Kann Defense", "Bronstein-Larsen Variation", "e4 c6 d4 d5 Nc3 dxe4 Nxe4 Nf6 Nxf6+ gxf6"},
+	{"B17", "Caro-Kann Defense", "Karpov Variation", "e4 c6 d4 d5 Nc3 dxe4 Nxe4 Nd7"},
+	{"B18", "Caro-Kann Defense", "Classical Variation", "e4 c6 d4 d5 Nc3 dxe4 Nxe4 Bf5"},
+	{"B19", "Caro-Kann Defense", "Classical Variation, Main Line", "e4 c6 d4 d5 Nc3 dxe4 Nxe4 Bf5 Ng3 Bg6 h4 h6 Nf3 Nd7"},
+	{"B20", "Sicilian Defense", "", "e4 c5"},
+	{"B21", "Sicilian Defense", "Smith-Morra Gambit", "e4 c5 d4 cxd4 c3"},
+	{"B22", "Sicilian Defense", "Alapin Variation", "e4 c5 c3"},
+	{"B23", "Sicilian Defense", "Closed", "e4 c5 Nc3"},
+	{"B24", "Sicilian Defense", "Closed", "e4 c5 Nc3 Nc6 g3"},
+	{"B25", "Sicilian Defense", "Closed", "e4 c5 Nc3 Nc6 g3 g6 Bg2 Bg7 d3 d6"},
+	{"B26", "Sicilian Defense", "Closed", "e4 c5 Nc3 Nc6 g3 g6 Bg2 Bg7 d3 d6 Be3"},
+	{"B27", "Sicilian Defense", "", "e4 c5 Nf3"},
+	{"B27", "Sicilian Defense", "Hyperaccelerated Dragon", "e4 c5 Nf3 g6"},
+	{"B28", "Sicilian Defense", "O'Kelly Variation", "e4 c5 Nf3 a6"},
+	{"B29", "Sicilian Defense", "Nimzowitsch Variation", "e4 c5 Nf3 Nf6"},
+	{"B30", "Sicilian Defense", "Old Sicilian", "e4 c5 Nf3 Nc6"},
+	{"B30", "Sicilian Defense", "Rossolimo Variation", "e4 c5 Nf3 Nc6 Bb5"},
+	{"B31", "Sicilian Defense", "Nyezhmetdinov-Rossolimo Attack, Fianchetto Variation", "e4 c5 Nf3 Nc6 Bb5 g6"},
+	{"B32", "Sicilian Defense", "Open", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4"},
+	{"B33", "Sicilian Defense", "Sveshnikov Variation", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 Nf6 Nc3 e5"},
+	{"B34", "Sicilian Defense", "Accelerated Dragon", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6"},
+	{"B35", "Sicilian Defense", "Accelerated Dragon, Modern Bc4 Variation", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6 Nc3 Bg7 Be3 Nf6 Bc4"},
+	{"B36", "Sicilian Defense", "Accelerated Dragon, Maroczy Bind", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6 c4"},
+	{"B37", "Sicilian Defense", "Accelerated Dragon, Maroczy Bind", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6 c4 Bg7"},
+	{"B38", "Sicilian Defense", "Accelerated Dragon, Maroczy Bind, Main Line", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6 c4 Bg7 Be3"},
+	{"B39", "Sicilian Defense", "Accelerated Dragon, Maroczy Bind, Breyer Variation", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6 c4 Bg7 Be3 Nf6 Nc3 Ng4"},
+	{"B40", "Sicilian Defense", "French Variation", "e4 c5 Nf3 e6"},
+	{"B41", "Sicilian Defense", "Kan Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 a6"},
+	{"B42", "Sicilian Defense", "Kan Variation, Modern Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 a6 Bd3"},
+	{"B43", "Sicilian Defense", "Kan Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 a6 Nc3"},
+	{"B44", "Sicilian Defense", "Taimanov Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6"},
+	{"B45", "Sicilian Defense", "Taimanov Variation, Normal Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6 Nc3"},
+	{"B46", "Sicilian Defense", "Taimanov Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6 Nc3 a6"},
+	{"B47", "Sicilian Defense", "Taimanov Variation, Bastrikov Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6 Nc3 Qc7"},
+	{"B48", "Sicilian Defense", "Taimanov Variation, Bastrikov Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6 Nc3 Qc7 Be3"},
+	{"B49", "Sicilian Defense", "Taimanov Variation, Bastrikov Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6 Nc3 Qc7 Be3 a6 Be2"},
+	{"B50", "Sicilian Defense", "Modern Variations", "e4 c5 Nf3 d6"},
+	{"B51", "Sicilian Defense", "Moscow Variation", "e4 c5 Nf3 d6 Bb5+"},
+	{"B52", "Sicilian Defense", "Canal Attack, Main Line", "e4 c5 Nf3 d6 Bb5+ Bd7"},
+	{"B53", "Sicilian Defense", "Chekhover Variation", "e4 c5 Nf3 d6 d4 cxd4 Qxd4"},
+	{"B54", "Sicilian Defense", "Open", "e4 c5 Nf3 d6 d4 cxd4 Nxd4"},
+	{"B55", "Sicilian Defense", "Prins Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 f3"},
+	{"B56", "Sicilian Defense", "Open", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3"},
+	{"B57", "Sicilian Defense", "Classical Variation, Sozin Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bc4"},
+	{"B58", "Sicilian Defense", "Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6"},
+	{"B59", "Sicilian Defense", "Boleslavsky Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Be2 e5 Nb3"},
+	{"B60", "Sicilian Defense", "Richter-Rauzer Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5"},
+	{"B61", "Sicilian Defense", "Richter-Rauzer Variation, Larsen Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 Bd7 Qd2"},
+	{"B62", "Sicilian Defense", "Richter-Rauzer Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6"},
+	{"B63", "Sicilian Defense", "Richter-Rauzer Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2"},
+	{"B64", "Sicilian Defense", "Richter-Rauzer Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2 Be7 O-O-O O-O f4"},
+	{"B65", "Sicilian Defense", "Richter-Rauzer Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2 Be7 O-O-O O-O f4 Nxd4 Qxd4"},
+	{"B66", "Sicilian Defense", "Richter-Rauzer Variation, Neo-Modern Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2 a6"},
+	{"B67", "Sicilian Defense", "Richter-Rauzer Variation, Neo-Modern Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2 a6 O-O-O Bd7"},
+	{"B68", "Sicilian Defense", "Richter-Rauzer Variation, Neo-Modern Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2 a6 O-O-O Bd7 f4 Be7"},
+	{"B69", "Sicilian Defense", "Richter-Rauzer Variation, Neo-Modern Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6 Bg5 e6 Qd2 a6 O-O-O Bd7 f4 Be7 Nf3 b5 Bxf6"},
+	{"B70", "Sicilian Defense", "Dragon Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6"},
+	{"B71", "Sicilian Defense", "Dragon Variation, Levenfish Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 f4"},
+	{"B72", "Sicilian Defense", "Dragon Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3"},
+	{"B73", "Sicilian Defense", "Dragon Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 Be2 Nc6 O-O"},
+	{"B74", "Sicilian Defense", "Dragon Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 Be2 Nc6 O-O O-O Nb3"},
+	{"B75", "Sicilian Defense", "Dragon Variation, Yugoslav Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 f3"},
+	{"B76", "Sicilian Defense", "Dragon Variation, Yugoslav Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 f3 O-O"},
+	{"B77", "Sicilian Defense", "Dragon Variation, Yugoslav Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 f3 O-O Qd2 Nc6 Bc4"},
+	{"B78", "Sicilian Defense", "Dragon Variation, Yugoslav Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 f3 O-O Qd2 Nc6 Bc4 Bd7 O-O-O"},
+	{"B79", "Sicilian Defense", "Dragon Variation, Yugoslav Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6 Be3 Bg7 f3 O-O Qd2 Nc6 Bc4 Bd7 O-O-O Qa5 Bb3 Rfc8 h4"},
+	{"B80", "Sicilian Defense", "Scheveningen Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6"},
+	{"B81", "Sicilian Defense", "Scheveningen Variation, Keres Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 g4"},
+	{"B82", "Sicilian Defense", "Scheveningen Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 f4"},
+	{"B83", "Sicilian Defense", "Scheveningen Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Be2"},
+	{"B84", "Sicilian Defense", "Scheveningen Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Be2 a6"},
+	{"B85", "Sicilian Defense", "Scheveningen Variation, Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Be2 a6 O-O Qc7 f4 Nc6"},
+	{"B86", "Sicilian Defense", "Sozin Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Bc4"},
+	{"B87", "Sicilian Defense", "Sozin Attack, Flank Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Bc4 a6 Bb3 b5"},
+	{"B88", "Sicilian Defense", "Sozin Attack, Leonhardt Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Bc4 Nc6"},
+	{"B89", "Sicilian Defense", "Sozin Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6 Bc4 Nc6 Be3"},
+	{"B90", "Sicilian Defense", "Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6"},
+	{"B90", "Sicilian Defense", "Najdorf Variation, English Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Be3"},
+	{"B91", "Sicilian Defense", "Najdorf Variation, Zagreb Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 g3"},
+	{"B92", "Sicilian Defense", "Najdorf Variation, Opocensky Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Be2"},
+	{"B93", "Sicilian Defense", "Najdorf Variation, Amsterdam Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 f4"},
+	{"B94", "Sicilian Defense", "Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5"},
+	{"B95", "Sicilian Defense", "Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5 e6"},
+	{"B96", "Sicilian Defense", "Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5 e6 f4"},
+	{"B97", "Sicilian Defense", "Najdorf Variation, Poisoned Pawn Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5 e6 f4 Qb6"},
+	{"B98", "Sicilian Defense", "Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5 e6 f4 Be7"},
+	{"B99", "Sicilian Defense", "Najdorf Variation, Main Line", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5 e6 f4 Be7 Qf3 Qc7 O-O-O Nbd7"},
+
+	{"C00", "French Defense", "", "e4 e6"},
+	{"C01", "French Defense", "Exchange Variation", "e4 e6 d4 d5 exd5"},
+	{"C02", "French Defense", "Advance Variation", "e4 e6 d4 d5 e5"},
+	{"C03", "French Defense", "Tarrasch Variation", "e4 e6 d4 d5 Nd2"},
+	{"C04", "French Defense", "Tarrasch Variation, Guimard Defense", "e4 e6 d4 d5 Nd2 Nc6"},
+	{"C05", "French Defense", "Tarrasch Variation, Closed Variation", "e4 e6 d4 d5 Nd2 Nf6"},
+	{"C06", "French Defense", "Tarrasch Variation, Closed Variation, Main Line", "e4 e6 d4 d5 Nd2 Nf6 e5 Nfd7 Bd3 c5 c3 Nc6 Ne2"},
+	{"C07", "French Defense", "Tarrasch Variation, Open System", "e4 e6 d4 d5 Nd2 c5"},
+	{"C08", "French Defense", "Tarrasch Variation, Open System", "e4 e6 d4 d5 Nd2 c5 exd5 exd5"},
+	{"C09", "French Defense", "Tarrasch Variation, Open System, Main Line", "e4 e6 d4 d5 Nd2 c5 exd5 exd5 Ngf3 Nc6"},
+	{"C10", "French Defense", "Paulsen Variation", "e4 e6 d4 d5 Nc3"},
+	{"C10", "French Defense", "Rubinstein Variation", "e4 e6 d4 d5 Nc3 dxe4"},
+	{"C11", "French Defense", "Classical Variation", "e4 e6 d4 d5 Nc3 Nf6"},
+	{"C12", "French Defense", "MacCutcheon Variation", "e4 e6 d4 d5 Nc3 Nf6 Bg5 Bb4"},
+	{"C13", "French Defense", "Classical Variation", "e4 e6 d4 d5 Nc3 Nf6 Bg5 Be7"},
+	{"C14", "French Defense", "Classical Variation, Normal Variation", "e4 e6 d4 d5 Nc3 Nf6 Bg5 Be7 e5 Nfd7 Bxe7 Qxe7"},
+	{"C15", "French Defense", "Winawer Variation", "e4 e6 d4 d5 Nc3 Bb4"},
+	{"C16", "French Defense", "Winawer Variation, Advance Variation", "e4 e6 d4 d5 Nc3 Bb4 e5"},
+	{"C17", "French Defense", "Winawer Variation, Advance Variation", "e4 e6 d4 d5 Nc3 Bb4 e5 c5"},
+	{"C18", "French Defense", "Winawer Variation, Advance Variation", "e4 e6 d4 d5 Nc3 Bb4 e5 c5 a3"},
+	{"C19", "French Defense", "Winawer Variation, Advance Variation", "e4 e6 d4 d5 Nc3 Bb4 e5 c5 a3 Bxc3+ bxc3 Ne7"},
+	{"C20", "King's Pawn Game", "", "e4 e5"},
+	{"C20", "King's Pawn Game", "Wayward Queen Attack", "e4 e5 Qh5"},
+	{"C21", "Center Game", "", "e4 e5 d4"},
+	{"C21", "Danish Gambit", "", "e4 e5 d4 exd4 c3"},
+	{"C22", "Center Game", "", "e4 e5 d4 exd4 Qxd4"},
+	{"C23", "Bishop's Opening", "", "e4 e5 Bc4"},
+	{"C24", "Bishop's Opening", "Berlin Defense", "e4 e5 Bc4 Nf6"},
+	{"C25", "Vienna Game", "", "e4 e5 Nc3"},
+	{"C26", "Vienna Game", "Falkbeer Variation", "e4 e5 Nc3 Nf6"},
+	{"C27", "Vienna Game", "Stanley Variation", "e4 e5 Nc3 Nf6 Bc4"},
+	{"C27", "Vienna Game", "Frankenstein-Dracula Variation", "e4 e5 Nc3 Nf6 Bc4 Nxe4 Qh5 Nd6 Bb3 Nc6 Nb5 g6 Qf3 f5 Qd5 Qe7 Nxc7+ Kd8 Nxa8 b6"},
+	{"C28", "Vienna Game", "Stanley Variation, Three Knights Variation", "e4 e5 Nc3 Nf6 Bc4 Nc6"},
+	{"C29", "Vienna Game", "Vienna Gambit", "e4 e5 Nc3 Nf6 f4"},
+	{"C30", "King's Gambit", "", "e4 e5 f4"},
+	{"C31", "King's Gambit Declined", "Falkbeer Countergambit", "e4 e5 f4 d5"},
+	{"C32", "King's Gambit Declined", "Falkbeer Countergambit, Modern Variation", "e4 e5 f4 d5 exd5 e4 d3 Nf6"},
+	{"C33", "King's Gambit Accepted", "", "e4 e5 f4 exf4"},
+	{"C34", "King's Gambit Accepted", "King's Knight's Gambit", "e4 e5 f4 exf4 Nf3"},
+	{"C35", "King's Gambit Accepted", "Cunningham Defense", "e4 e5 f4 exf4 Nf3 Be7"},
+	{"C36", "King's Gambit Accepted", "Modern Defense", "e4 e5 f4 exf4 Nf3 d5"},
+	{"C37", "King's Gambit Accepted", "Classical Variation", "e4 e5 f4 exf4 Nf3 g5"},
+	{"C38", "King's Gambit Accepted", "Traditional Variation", "e4 e5 f4 exf4 Nf3 g5 Bc4 Bg7"},
+	{"C39", "King's Gambit Accepted", "Allgaier Gambit", "e4 e5 f4 exf4 Nf3 g5 h4 g4 Ng5"},
+	{"C39", "King's Gambit Accepted", "Kieseritzky Gambit", "e4 e5 f4 exf4 Nf3 g5 h4 g4 Ne5"},
+	{"C40", "King's Knight Opening", "", "e4 e5 Nf3"},
+	{"C40", "Latvian Gambit", "", "e4 e5 Nf3 f5"},
+	{"C40", "Elephant Gambit", "", "e4 e5 Nf3 d5"},
+	{"C41", "Philidor Defense", "", "e4 e5 Nf3 d6"},
+	{"C42", "Petrov's Defense", "", "e4 e5 Nf3 Nf6"},
+	{"C42", "Petrov's Defense", "Classical Attack", "e4 e5 Nf3 Nf6 Nxe5 d6 Nf3 Nxe4 d4"},
+	{"C43", "Petrov's Defense", "Steinitz Attack", "e4 e5 Nf3 Nf6 d4"},
+	{"C44", "King's Pawn Game", "", "e4 e5 Nf3 Nc6"},
+	{"C44", "Ponziani Opening", "", "e4 e5 Nf3 Nc6 c3"},
+	{"C44", "Scotch Game", "", "e4 e5 Nf3 Nc6 d4"},
+	{"C45", "Scotch Game", "", "e4 e5 Nf3 Nc6 d4 exd4 Nxd4"},
+	{"C46", "Three Knights Opening", "", "e4 e5 Nf3 Nc6 Nc3"},
+	{"C47", "Four Knights Game", "", "e4 e5 Nf3 Nc6 Nc3 Nf6"},
+	{"C47", "Four Knights Game", "Scotch Variation", "e4 e5 Nf3 Nc6 Nc3 Nf6 d4"},
+	{"C48", "Four Knights Game", "Spanish Variation", "e4 e5 Nf3 Nc6 Nc3 Nf6 Bb5"},
+	{"C49", "Four Knights Game", "Double Spanish", "e4 e5 Nf3 Nc6 Nc3 Nf6 Bb5 Bb4"},
+	{"C50", "Italian Game", "", "e4 e5 Nf3 Nc6 Bc4"},
+	{"C50", "Italian Game", "Hungarian Defense", "e4 e5 Nf3 Nc6 Bc4 Be7"},
+	{"C50", "Italian Game", "Giuoco Piano", "e4 e5 Nf3 Nc6 Bc4 Bc5"},
+	{"C50", "Italian Game", "Giuoco Pianissimo", "e4 e5 Nf3 Nc6 Bc4 Bc5 d3"},
+	{"C51", "Italian Game", "Evans Gambit", "e4 e5 Nf3 Nc6 Bc4 Bc5 b4"},
+	{"C52", "Italian Game", "Evans Gambit, Main Line", "e4 e5 Nf3 Nc6 Bc4 Bc5 b4 Bxb4 c3 Ba5"},
+	{"C53", "Italian Game", "Classical Variation", "e4 e5 Nf3 Nc6 Bc4 Bc5 c3"},
+	{"C54", "Italian Game", "Classical Variation, Main Line", "e4 e5 Nf3 Nc6 Bc4 Bc5 c3 Nf6 d4 exd4 cxd4 Bb4+"},
+	{"C55", "Italian Game", "Two Knights Defense", "e4 e5 Nf3 Nc6 Bc4 Nf6"},
+	{"C56", "Italian Game", "Scotch Gambit, Two Knights Defense", "e4 e5 Nf3 Nc6 Bc4 Nf6 d4 exd4 O-O Nxe4"},
+	{"C57", "Italian Game", "Two Knights Defense, Knight Attack", "e4 e5 Nf3 Nc6 Bc4 Nf6 Ng5"},
+	{"C57", "Italian Game", "Two Knights Defense, Fried Liver Attack", "e4 e5 Nf3 Nc6 Bc4 Nf6 Ng5 d5 exd5 Nxd5 Nxf7"},
+	{"C58", "Italian Game", "Two Knights Defense, Polerio Defense", "e4 e5 Nf3 Nc6 Bc4 Nf6 Ng5 d5 exd5 Na5"},
+	{"C59", "Italian Game", "Two Knights Defense, Polerio Defense", "e4 e5 Nf3 Nc6 Bc4 Nf6 Ng5 d5 exd5 Na5 Bb5+ c6 dxc6 bxc6 Be2 h6"},
+	{"C60", "Ruy Lopez", "", "e4 e5 Nf3 Nc6 Bb5"},
+	{"C61", "Ruy Lopez", "Bird Variation", "e4 e5 Nf3 Nc6 Bb5 Nd4"},
+	{"C62", "Ruy Lopez", "Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 d6"},
+	{"C63", "Ruy Lopez", "Schliemann Defense", "e4 e5 Nf3 Nc6 Bb5 f5"},
+	{"C64", "Ruy Lopez", "Classical Variation", "e4 e5 Nf3 Nc6 Bb5 Bc5"},
+	{"C65", "Ruy Lopez", "Berlin Defense", "e4 e5 Nf3 Nc6 Bb5 Nf6"},
+	{"C66", "Ruy Lopez", "Berlin Defense, Improved Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 Nf6 O-O d6"},
+	{"C67", "Ruy Lopez", "Berlin Defense, Berlin Wall", "e4 e5 Nf3 Nc6 Bb5 Nf6 O-O Nxe4 d4 Nd6 Bxc6 dxc6 dxe5 Nf5 Qxd8+ Kxd8"},
+	{"C68", "Ruy Lopez", "Exchange Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Bxc6"},
+	{"C69", "Ruy Lopez", "Exchange Variation, Gligoric Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Bxc6 dxc6 O-O f6"},
+	{"C70", "Ruy Lopez", "Morphy Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4"},
+	{"C71", "Ruy Lopez", "Morphy Defense, Modern Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 d6"},
+	{"C72", "Ruy Lopez", "Morphy Defense, Modern Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 d6 O-O"},
+	{"C73", "Ruy Lopez", "Morphy Defense, Modern Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 d6 Bxc6+ bxc6 d4"},
+	{"C74", "Ruy Lopez", "Morphy Defense, Modern Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 d6 c3"},
+	{"C75", "Ruy Lopez", "Morphy Defense, Modern Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 d6 c3 Bd7"},
+	{"C76", "Ruy Lopez", "Morphy Defense, Modern Steinitz Defense, Fianchetto Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 d6 c3 Bd7 d4 g6"},
+	{"C77", "Ruy Lopez", "Morphy Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6"},
+	{"C78", "Ruy Lopez", "Morphy Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O"},
+	{"C79", "Ruy Lopez", "Morphy Defense, Steinitz Deferred", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O d6"},
+	{"C80", "Ruy Lopez", "Open Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Nxe4"},
+	{"C81", "Ruy Lopez", "Open Variation, Howell Attack", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Nxe4 d4 b5 Bb3 d5 dxe5 Be6 Qe2"},
+	{"C82", "Ruy Lopez", "Open Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Nxe4 d4 b5 Bb3 d5 dxe5 Be6 c3"},
+	{"C83", "Ruy Lopez", "Open Variation, Classical Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Nxe4 d4 b5 Bb3 d5 dxe5 Be6 c3 Be7"},
+	{"C84", "Ruy Lopez", "Closed Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7"},
+	{"C85", "Ruy Lopez", "Exchange Variation Doubly Deferred", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Bxc6 dxc6"},
+	{"C86", "Ruy Lopez", "Worrall Attack", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Qe2"},
+	{"C87", "Ruy Lopez", "Closed, Averbakh Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 d6"},
+	{"C88", "Ruy Lopez", "Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3"},
+	{"C89", "Ruy Lopez", "Marshall Attack", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d5"},
+	{"C90", "Ruy Lopez", "Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6"},
+	{"C91", "Ruy Lopez", "Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 d4"},
+	{"C92", "Ruy Lopez", "Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3"},
+	{"C93", "Ruy Lopez", "Closed, Smyslov Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 h6"},
+	{"C94", "Ruy Lopez", "Closed, Breyer Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 Nb8"},
+	{"C95", "Ruy Lopez", "Closed, Breyer Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 Nb8 d4"},
+	{"C96", "Ruy Lopez", "Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 Na5 Bc2"},
+	{"C97", "Ruy Lopez", "Closed, Chigorin Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 Na5 Bc2 c5 d4 Qc7"},
+	{"C98", "Ruy Lopez", "Closed, Chigorin Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 Na5 Bc2 c5 d4 Qc7 Nbd2 Nc6"},
+	{"C99", "Ruy Lopez", "Closed, Chigorin Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d6 h3 Na5 Bc2 c5 d4 Qc7 Nbd2 cxd4 cxd4"},
+
+	{"D00", "Queen's Pawn Game", "", "d4 d5"},
+	{"D00", "Blackmar-Diemer Gambit", "", "d4 d5 e4"},
+	{"D00", "Queen's Pawn Game", "Accelerated London System", "d4 d5 Bf4"},
+	{"D01", "Richter-Veresov Attack", "", "d4 d5 Nc3 Nf6 Bg5"},
+	{"D02", "Queen's Pawn Game", "", "d4 d5 Nf3"},
+	{"D02", "Queen's Pawn Game", "London System", "d4 d5 Nf3 Nf6 Bf4"},
+	{"D03", "Queen's Pawn Game", "Torre Attack", "d4 d5 Nf3 Nf6 Bg5"},
+	{"D04", "Queen's Pawn Game", "Colle System", "d4 d5 Nf3 Nf6 e3"},
+	{"D05", "Queen's Pawn Game", "Colle System, Main Line", "d4 d5 Nf3 Nf6 e3 e6 Bd3"},
+	{"D06", "Queen's Gambit", "", "d4 d5 c4"},
+	{"D07", "Queen's Gambit Declined", "Chigorin Defense", "d4 d5 c4 Nc6"},
+	{"D08", "Queen's Gambit Declined", "Albin Countergambit", "d4 d5 c4 e5"},
+	{"D09", "Queen's Gambit Declined", "Albin Countergambit, Fianchetto Variation", "d4 d5 c4 e5 dxe5 d4 Nf3 Nc6 g3"},
+	{"D10", "Slav Defense", "", "d4 d5 c4 c6"},
+	{"D11", "Slav Defense", "Modern Line", "d4 d5 c4 c6 Nf3"},
+	{"D12", "Slav Defense", "Quiet Variation", "d4 d5 c4 c6 Nf3 Nf6 e3 Bf5"},
+	{"D13", "Slav Defense", "Exchange Variation", "d4 d5 c4 c6 Nf3 Nf6 cxd5 cxd5"},
+	{"D14", "Slav Defense", "Exchange Variation, Symmetrical Line", "d4 d5 c4 c6 Nf3 Nf6 cxd5 cxd5 Nc3 Nc6 Bf4 Bf5"},
+	{"D15", "Slav Defense", "Three Knights Variation", "d4 d5 c4 c6 Nf3 Nf6 Nc3"},
+	{"D16", "Slav Defense", "Alapin Variation", "d4 d5 c4 c6 Nf3 Nf6 Nc3 dxc4 a4"},
+	{"D17", "Slav Defense", "Czech Variation", "d4 d5 c4 c6 Nf3 Nf6 Nc3 dxc4 a4 Bf5"},
+	{"D18", "Slav Defense", "Dutch Variation", "d4 d5 c4 c6 Nf3 Nf6 Nc3 dxc4 a4 Bf5 e3"},
+	{"D19", "Slav Defense", "Dutch Variation, Main Line", "d4 d5 c4 c6 Nf3 Nf6 Nc3 dxc4 a4 Bf5 e3 e6 Bxc4 Bb4 O-O"},
+	{"D20", "Queen's Gambit Accepted", "", "d4 d5 c4 dxc4"},
+	{"D21", "Queen's Gambit Accepted", "Normal Variation", "d4 d5 c4 dxc4 Nf3"},
+	{"D22", "Queen's Gambit Accepted", "Alekhine Defense", "d4 d5 c4 dxc4 Nf3 a6"},
+	{"D23", "Queen's Gambit Accepted", "Normal Variation", "d4 d5 c4 dxc4 Nf3 Nf6"},
+	{"D24", "Queen's Gambit Accepted", "Normal Variation", "d4 d5 c4 dxc4 Nf3 Nf6 Nc3"},
+	{"D25", "Queen's Gambit Accepted", "Normal Variation", "d4 d5 c4 dxc4 Nf3 Nf6 e3"},
+	{"D26", "Queen's Gambit Accepted", "Classical Defense", "d4 d5 c4 dxc4 Nf3 Nf6 e3 e6"},
+	{"D27", "Queen's Gambit Accepted", "Classical Defense, Main Line", "d4 d5 c4 dxc4 Nf3 Nf6 e3 e6 Bxc4 c5 O-O a6"},
+	{"D28", "Queen's Gambit Accepted", "Classical Defense, Alekhine System", "d4 d5 c4 dxc4 Nf3 Nf6 e3 e6 Bxc4 c5 O-O a6 Qe2"},
+	{"D29", "Queen's Gambit Accepted", "Classical Defense, Alekhine System, Main Line", "d4 d5 c4 dxc4 Nf3 Nf6 e3 e6 Bxc4 c5 O-O a6 Qe2 b5 Bb3 Bb7"},
+	{"D30", "Queen's Gambit Declined", "", "d4 d5 c4 e6"},
+	{"D31", "Queen's Gambit Declined", "Queen's Knight Variation", "d4 d5 c4 e6 Nc3"},
+	{"D32", "Tarrasch Defense", "", "d4 d5 c4 e6 Nc3 c5"},
+	{"D33", "Tarrasch Defense", "Schlechter-Rubinstein System", "d4 d5 c4 e6 Nc3 c5 cxd5 exd5 Nf3 Nc6 g3"},
+	{"D34", "Tarrasch Defense", "Classical Variation", "d4 d5 c4 e6 Nc3 c5 cxd5 exd5 Nf3 Nc6 g3 Nf6 Bg2 Be7"},
+	{"D35", "Queen's Gambit Declined", "Exchange Variation", "d4 d5 c4 e6 Nc3 Nf6 cxd5"},
+	{"D36", "Queen's Gambit Declined", "Exchange Variation, Positional Variation", "d4 d5 c4 e6 Nc3 Nf6 cxd5 exd5 Bg5 c6 Qc2"},
+	{"D37", "Queen's Gambit Declined", "Three Knights Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3"},
+	{"D38", "Queen's Gambit Declined", "Ragozin Defense", "d4 d5 c4 e6 Nc3 Nf6 Nf3 Bb4"},
+	{"D39", "Queen's Gambit Declined", "Ragozin Defense, Vienna Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3 Bb4 Bg5 dxc4"},
+	{"D40", "Queen's Gambit Declined", "Semi-Tarrasch Defense", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c5"},
+	{"D41", "Queen's Gambit Declined", "Semi-Tarrasch Defense, Exchange Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c5 cxd5"},
+	{"D42", "Queen's Gambit Declined", "Semi-Tarrasch Defense, Main Line", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c5 cxd5 Nxd5 e3 Nc6 Bd3"},
+	{"D43", "Semi-Slav Defense", "", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6"},
+	{"D44", "Semi-Slav Defense", "Botvinnik System", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6 Bg5 dxc4"},
+	{"D45", "Semi-Slav Defense", "Normal Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6 e3"},
+	{"D46", "Semi-Slav Defense", "Main Line", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6 e3 Nbd7 Bd3"},
+	{"D47", "Semi-Slav Defense", "Meran Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6 e3 Nbd7 Bd3 dxc4 Bxc4 b5"},
+	{"D48", "Semi-Slav Defense", "Meran Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6 e3 Nbd7 Bd3 dxc4 Bxc4 b5 Bd3 a6"},
+	{"D49", "Semi-Slav Defense", "Meran Variation, Blumenfeld Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3 c6 e3 Nbd7 Bd3 dxc4 Bxc4 b5 Bd3 a6 e4 c5 e5 cxd4 Nxb5"},
+	{"D50", "Queen's Gambit Declined", "Modern Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5"},
+	{"D51", "Queen's Gambit Declined", "Modern Variation, Knight Defense", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Nbd7"},
+	{"D52", "Queen's Gambit Declined", "Cambridge Springs Defense", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Nbd7 e3 c6 Nf3 Qa5"},
+	{"D53", "Queen's Gambit Declined", "Modern Variation, Normal Line", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7"},
+	{"D54", "Queen's Gambit Declined", "Anti-Neo-Orthodox Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Rc1"},
+	{"D55", "Queen's Gambit Declined", "Neo-Orthodox Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3"},
+	{"D56", "Queen's Gambit Declined", "Neo-Orthodox Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 h6 Bh4"},
+	{"D57", "Queen's Gambit Declined", "Lasker Defense", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 h6 Bh4 Ne4"},
+	{"D58", "Queen's Gambit Declined", "Tartakower Defense", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 h6 Bh4 b6"},
+	{"D59", "Queen's Gambit Declined", "Tartakower Defense, Exchange Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 h6 Bh4 b6 cxd5 Nxd5"},
+	{"D60", "Queen's Gambit Declined", "Orthodox Defense", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7"},
+	{"D61", "Queen's Gambit Declined", "Orthodox Defense, Rubinstein Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Qc2"},
+	{"D62", "Queen's Gambit Declined", "Orthodox Defense, Rubinstein Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Qc2 c5 cxd5"},
+	{"D63", "Queen's Gambit Declined", "Orthodox Defense, Main Line", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1"},
+	{"D64", "Queen's Gambit Declined", "Orthodox Defense, Rubinstein Attack", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1 c6 Qc2"},
+	{"D65", "Queen's Gambit Declined", "Orthodox Defense, Rubinstein Attack", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1 c6 Qc2 a6 cxd5"},
+	{"D66", "Queen's Gambit Declined", "Orthodox Defense, Bd3 Line", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1 c6 Bd3"},
+	{"D67", "Queen's Gambit Declined", "Orthodox Defense, Capablanca Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1 c6 Bd3 dxc4 Bxc4 Nd5"},
+	{"D68", "Queen's Gambit Declined", "Orthodox Defense, Classical Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1 c6 Bd3 dxc4 Bxc4 Nd5 Bxe7 Qxe7 O-O Nxc3 Rxc3 e5"},
+	{"D69", "Queen's Gambit Declined", "Orthodox Defense, Classical Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5 Be7 e3 O-O Nf3 Nbd7 Rc1 c6 Bd3 dxc4 Bxc4 Nd5 Bxe7 Qxe7 O-O Nxc3 Rxc3 e5 dxe5 Nxe5 Nxe5 Qxe5"},
+	{"D70", "Neo-Grunfeld Defense", "", "d4 Nf6 c4 g6 f3 d5"},
+	{"D71", "Neo-Grunfeld Defense", "", "d4 Nf6 c4 g6 g3 d5"},
+	{"D72", "Neo-Grunfeld Defense", "Classical Variation, Big Center", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 cxd5 Nxd5 e4 Nb6 Ne2"},
+	{"D73", "Neo-Grunfeld Defense", "Classical Variation", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3"},
+	{"D74", "Neo-Grunfeld Defense", "Exchange Variation", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3 O-O cxd5 Nxd5 O-O"},
+	{"D75", "Neo-Grunfeld Defense", "Exchange Variation", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3 O-O cxd5 Nxd5 O-O c5 Nc3"},
+	{"D76", "Neo-Grunfeld Defense", "Exchange Variation", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3 O-O cxd5 Nxd5 O-O Nb6"},
+	{"D77", "Neo-Grunfeld Defense", "Classical Variation, Original Defense", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3 O-O O-O"},
+	{"D78", "Neo-Grunfeld Defense", "Classical Variation, Original Defense", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3 O-O O-O c6"},
+	{"D79", "Neo-Grunfeld Defense", "Ultra-Delayed Exchange Variation", "d4 Nf6 c4 g6 g3 d5 Bg2 Bg7 Nf3 O-O O-O c6 cxd5 cxd5"},
+	{"D80", "Grunfeld Defense", "", "d4 Nf6 c4 g6 Nc3 d5"},
+	{"D81", "Grunfeld Defense", "Russian Variation, Accelerated Variation", "d4 Nf6 c4 g6 Nc3 d5 Qb3"},
+	{"D82", "Grunfeld Defense", "Brinckmann Attack", "d4 Nf6 c4 g6 Nc3 d5 Bf4"},
+	{"D83", "Grunfeld Defense", "Brinckmann Attack, Grunfeld Gambit", "d4 Nf6 c4 g6 Nc3 d5 Bf4 Bg7 e3 O-O"},
+	{"D84", "Grunfeld Defense", "Brinckmann Attack, Grunfeld Gambit Accepted", "d4 Nf6 c4 g6 Nc3 d5 Bf4 Bg7 e3 O-O cxd5 Nxd5 Nxd5 Qxd5 Bxc7"},
+	{"D85", "Grunfeld Defense", "Exchange Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5"},
+	{"D85", "Grunfeld Defense", "Exchange Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5 e4 Nxc3 bxc3"},
+	{"D86", "Grunfeld Defense", "Exchange Variation, Classical Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5 e4 Nxc3 bxc3 Bg7 Bc4"},
+	{"D87", "Grunfeld Defense", "Exchange Variation, Classical Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5 e4 Nxc3 bxc3 Bg7 Bc4 O-O Ne2 c5"},
+	{"D88", "Grunfeld Defense", "Exchange Variation, Classical Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5 e4 Nxc3 bxc3 Bg7 Bc4 O-O Ne2 c5 O-O Nc6 Be3 cxd4 cxd4"},
+	{"D89", "Grunfeld Defense", "Exchange Variation, Classical Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5 e4 Nxc3 bxc3 Bg7 Bc4 O-O Ne2 c5 O-O Nc6 Be3 cxd4 cxd4 Bg4 f3 Na5 Bd3 Be6"},
+	{"D90", "Grunfeld Defense", "Three Knights Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7"},
+	{"D91", "Grunfeld Defense", "Three Knights Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Bg5"},
+	{"D92", "Grunfeld Defense", "Three Knights Variation, Hungarian Attack", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Bf4"},
+	{"D93", "Grunfeld Defense", "Three Knights Variation, Hungarian Attack", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Bf4 O-O e3"},
+	{"D94", "Grunfeld Defense", "Three Knights Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 e3"},
+	{"D95", "Grunfeld Defense", "Three Knights Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 e3 O-O Qb3"},
+	{"D96", "Grunfeld Defense", "Russian Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Qb3"},
+	{"D97", "Grunfeld Defense", "Russian Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Qb3 dxc4 Qxc4 O-O e4"},
+	{"D98", "Grunfeld Defense", "Russian Variation, Smyslov Variation", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Qb3 dxc4 Qxc4 O-O e4 Bg4"},
+	{"D99", "Grunfeld Defense", "Russian Variation, Smyslov Variation, Main Line", "d4 Nf6 c4 g6 Nc3 d5 Nf3 Bg7 Qb3 dxc4 Qxc4 O-O e4 Bg4 Be3 Nfd7 Qb3"},
+
+	{"E00", "Indian Defense", "", "d4 Nf6 c4 e6"},
+	{"E01", "Catalan Opening", "", "d4 Nf6 c4 e6 g3"},
+	{"E02", "Catalan Opening", "Open Defense", "d4 Nf6 c4 e6 g3 d5 Bg2 dxc4 Qa4+"},
+	{"E03", "Catalan Opening", "Open Defense", "d4 Nf6 c4 e6 g3 d5 Bg2 dxc4 Qa4+ Nbd7 Qxc4"},
+	{"E04", "Catalan Opening", "Open Defense", "d4 Nf6 c4 e6 g3 d5 Bg2 dxc4 Nf3"},
+	{"E05", "Catalan Opening", "Open Defense, Classical Line", "d4 Nf6 c4 e6 g3 d5 Bg2 dxc4 Nf3 Be7"},
+	{"E06", "Catalan Opening", "Closed Variation", "d4 Nf6 c4 e6 g3 d5 Bg2 Be7 Nf3"},
+	{"E07", "Catalan Opening", "Closed Variation", "d4 Nf6 c4 e6 g3 d5 Bg2 Be7 Nf3 O-O O-O Nbd7"},
+	{"E08", "Catalan Opening", "Closed Variation", "d4 Nf6 c4 e6 g3 d5 Bg2 Be7 Nf3 O-O O-O Nbd7 Qc2"},
+	{"E09", "Catalan Opening", "Closed Variation, Main Line", "d4 Nf6 c4 e6 g3 d5 Bg2 Be7 Nf3 O-O O-O Nbd7 Qc2 c6 Nbd2"},
+	{"E10", "Indian Defense", "", "d4 Nf6 c4 e6 Nf3"},
+	{"E11", "Bogo-Indian Defense", "", "d4 Nf6 c4 e6 Nf3 Bb4+"},
+	{"E12", "Queen's Indian Defense", "", "d4 Nf6 c4 e6 Nf3 b6"},
+	{"E13", "Queen's Indian Defense", "Kasparov Variation", "d4 Nf6 c4 e6 Nf3 b6 Nc3 Bb7 Bg5"},
+	{"E14", "Queen's Indian Defense", "Spassky System", "d4 Nf6 c4 e6 Nf3 b6 e3"},
+	{"E15", "Queen's Indian Defense", "Fianchetto Variation", "d4 Nf6 c4 e6 Nf3 b6 g3"},
+	{"E16", "Queen's Indian Defense", "Capablanca Variation", "d4 Nf6 c4 e6 Nf3 b6 g3 Bb7 Bg2 Bb4+"},
+	{"E17", "Queen's Indian Defense", "Fianchetto Variation, Traditional Variation", "d4 Nf6 c4 e6 Nf3 b6 g3 Bb7 Bg2 Be7"},
+	{"E18", "Queen's Indian Defense", "Fianchetto Variation, Traditional Variation", "d4 Nf6 c4 e6 Nf3 b6 g3 Bb7 Bg2 Be7 O-O O-O Nc3"},
+	{"E19", "Queen's Indian Defense", "Fianchetto Variation, Traditional Variation, Main Line", "d4 Nf6 c4 e6 Nf3 b6 g3 Bb7 Bg2 Be7 O-O O-O Nc3 Ne4 Qc2"},
+	{"E20", "Nimzo-Indian Defense", "", "d4 Nf6 c4 e6 Nc3 Bb4"},
+	{"E21", "Nimzo-Indian Defense", "Three Knights Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Nf3"},
+	{"E22", "Nimzo-Indian Defense", "Spielmann Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qb3"},
+	{"E23", "Nimzo-Indian Defense", "Spielmann Variation, Karlsbad Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qb3 c5 dxc5 Nc6"},
+	{"E24", "Nimzo-Indian Defense", "Samisch Variation", "d4 Nf6 c4 e6 Nc3 Bb4 a3 Bxc3+ bxc3"},
+	{"E25", "Nimzo-Indian Defense", "Samisch Variation", "d4 Nf6 c4 e6 Nc3 Bb4 a3 Bxc3+ bxc3 c5 f3 d5 cxd5"},
+	{"E26", "Nimzo-Indian Defense", "Samisch Variation", "d4 Nf6 c4 e6 Nc3 Bb4 a3 Bxc3+ bxc3 c5 e3"},
+	{"E27", "Nimzo-Indian Defense", "Samisch Variation", "d4 Nf6 c4 e6 Nc3 Bb4 a3 Bxc3+ bxc3 O-O"},
+	{"E28", "Nimzo-Indian Defense", "Samisch Variation", "d4 Nf6 c4 e6 Nc3 Bb4 a3 Bxc3+ bxc3 O-O e3"},
+	{"E29", "Nimzo-Indian Defense", "Samisch Variation, Main Line", "d4 Nf6 c4 e6 Nc3 Bb4 a3 Bxc3+ bxc3 O-O e3 c5 Bd3 Nc6"},
+	{"E30", "Nimzo-Indian Defense", "Leningrad Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Bg5"},
+	{"E31", "Nimzo-Indian Defense", "Leningrad Variation, Main Line", "d4 Nf6 c4 e6 Nc3 Bb4 Bg5 h6 Bh4 c5 d5 d6"},
+	{"E32", "Nimzo-Indian Defense", "Classical Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2"},
+	{"E33", "Nimzo-Indian Defense", "Classical Variation, Zurich Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 Nc6"},
+	{"E34", "Nimzo-Indian Defense", "Classical Variation, Noa Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 d5"},
+	{"E35", "Nimzo-Indian Defense", "Classical Variation, Noa Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 d5 cxd5 exd5"},
+	{"E36", "Nimzo-Indian Defense", "Classical Variation, Noa Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 d5 a3"},
+	{"E37", "Nimzo-Indian Defense", "Classical Variation, Noa Variation, Main Line", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 d5 a3 Bxc3+ Qxc3 Ne4"},
+	{"E38", "Nimzo-Indian Defense", "Classical Variation, Berlin Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 c5"},
+	{"E39", "Nimzo-Indian Defense", "Classical Variation, Berlin Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2 c5 dxc5 O-O"},
+	{"E40", "Nimzo-Indian Defense", "Rubinstein Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3"},
+	{"E41", "Nimzo-Indian Defense", "Hubner Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 c5"},
+	{"E42", "Nimzo-Indian Defense", "Hubner Variation, Rubinstein Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 c5 Ne2"},
+	{"E43", "Nimzo-Indian Defense", "St. Petersburg Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 b6"},
+	{"E44", "Nimzo-Indian Defense", "Fischer Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 b6 Ne2"},
+	{"E45", "Nimzo-Indian Defense", "Normal Variation, Bronstein Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 b6 Ne2 Ba6"},
+	{"E46", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O"},
+	{"E47", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Bd3"},
+	{"E48", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Bd3 d5"},
+	{"E49", "Nimzo-Indian Defense", "Normal Variation, Botvinnik System", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Bd3 d5 a3 Bxc3+ bxc3"},
+	{"E50", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3"},
+	{"E51", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5"},
+	{"E52", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 b6"},
+	{"E53", "Nimzo-Indian Defense", "Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5"},
+	{"E54", "Nimzo-Indian Defense", "Normal Variation, Gligoric System", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5 O-O dxc4 Bxc4"},
+	{"E55", "Nimzo-Indian Defense", "Normal Variation, Gligoric System", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5 O-O dxc4 Bxc4 Nbd7"},
+	{"E56", "Nimzo-Indian Defense", "Normal Variation, Bernstein Defense", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5 O-O Nc6"},
+	{"E57", "Nimzo-Indian Defense", "Normal Variation, Bernstein Defense", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5 O-O Nc6 a3 dxc4 Bxc4 cxd4"},
+	{"E58", "Nimzo-Indian Defense", "Normal Variation, Bernstein Defense", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5 O-O Nc6 a3 Bxc3 bxc3"},
+	{"E59", "Nimzo-Indian Defense", "Normal Variation, Main Line", "d4 Nf6 c4 e6 Nc3 Bb4 e3 O-O Nf3 d5 Bd3 c5 O-O Nc6 a3 Bxc3 bxc3 dxc4 Bxc4"},
+	{"E60", "King's Indian Defense", "", "d4 Nf6 c4 g6"},
+	{"E61", "King's Indian Defense", "", "d4 Nf6 c4 g6 Nc3 Bg7"},
+	{"E62", "King's Indian Defense", "Fianchetto Variation", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3"},
+	{"E63", "King's Indian Defense", "Fianchetto Variation, Panno Variation", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 Nc6 O-O a6"},
+	{"E64", "King's Indian Defense", "Fianchetto Variation, Yugoslav System", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 c5"},
+	{"E65", "King's Indian Defense", "Fianchetto Variation, Yugoslav System", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 c5 O-O Nc6"},
+	{"E66", "King's Indian Defense", "Fianchetto Variation, Yugoslav System", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 c5 O-O Nc6 d5 Na5"},
+	{"E67", "King's Indian Defense", "Fianchetto Variation, Classical Fianchetto", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 Nbd7"},
+	{"E68", "King's Indian Defense", "Fianchetto Variation, Classical Fianchetto", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 Nbd7 O-O e5 e4"},
+	{"E69", "King's Indian Defense", "Fianchetto Variation, Classical Main Line", "d4 Nf6 c4 g6 Nc3 Bg7 Nf3 d6 g3 O-O Bg2 Nbd7 O-O e5 e4 c6 h3"},
+	{"E70", "King's Indian Defense", "Normal Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6"},
+	{"E71", "King's Indian Defense", "Makogonov Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 h3"},
+	{"E72", "King's Indian Defense", "Pomar System", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 g3"},
+	{"E73", "King's Indian Defense", "Averbakh Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Be2 O-O Bg5"},
+	{"E74", "King's Indian Defense", "Averbakh Variation, Benoni Defense", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Be2 O-O Bg5 c5"},
+	{"E75", "King's Indian Defense", "Averbakh Variation, Main Line", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Be2 O-O Bg5 c5 d5 e6"},
+	{"E76", "King's Indian Defense", "Four Pawns Attack", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f4"},
+	{"E77", "King's Indian Defense", "Four Pawns Attack", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f4 O-O Be2"},
+	{"E78", "King's Indian Defense", "Four Pawns Attack", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f4 O-O Be2 c5 Nf3"},
+	{"E79", "King's Indian Defense", "Four Pawns Attack, Main Line", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f4 O-O Be2 c5 Nf3 cxd4 Nxd4 Nc6 Be3"},
+	{"E80", "King's Indian Defense", "Samisch Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3"},
+	{"E81", "King's Indian Defense", "Samisch Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O"},
+	{"E82", "King's Indian Defense", "Samisch Variation, Double Fianchetto", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 b6"},
+	{"E83", "King's Indian Defense", "Samisch Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 Nc6"},
+	{"E84", "King's Indian Defense", "Samisch Variation, Panno Main Line", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 Nc6 Nge2 a6 Qd2 Rb8"},
+	{"E85", "King's Indian Defense", "Samisch Variation, Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 e5"},
+	{"E86", "King's Indian Defense", "Samisch Variation, Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 e5 Nge2 c6"},
+	{"E87", "King's Indian Defense", "Samisch Variation, Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 e5 d5"},
+	{"E88", "King's Indian Defense", "Samisch Variation, Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 e5 d5 c6"},
+	{"E89", "King's Indian Defense", "Samisch Variation, Orthodox Main Line", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3 O-O Be3 e5 d5 c6 Nge2 cxd5"},
+	{"E90", "King's Indian Defense", "Normal Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3"},
+	{"E91", "King's Indian Defense", "Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2"},
+	{"E92", "King's Indian Defense", "Classical Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5"},
+	{"E93", "King's Indian Defense", "Petrosian Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 d5 Nbd7"},
+	{"E94", "King's Indian Defense", "Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O"},
+	{"E95", "King's Indian Defense", "Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O Nbd7 Re1"},
+	{"E96", "King's Indian Defense", "Orthodox Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O Nbd7 Re1 c6 Bf1 a5"},
+	{"E97", "King's Indian Defense", "Orthodox Variation, Aronin-Taimanov Defense", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O Nc6"},
+	{"E98", "King's Indian Defense", "Orthodox Variation, Classical System", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O Nc6 d5 Ne7 Ne1"},
+	{"E99", "King's Indian Defense", "Orthodox Variation, Classical System, Main Line", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O Nc6 d5 Ne7 Ne1 Nd7 f3 f5"},
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestECOTable(t *testing.T) {
+	var index = ecoPositions()
+	var codes = make(map[string]bool)
+	for _, line := range ecoTable {
+		codes[line.eco] = true
+		var positions, err = ParseMoves(initialPosition(), line.moves)
+		if err != nil {
+			t.Fatal(line.eco, err)
+		}
+		var opening, ok = ClassifyOpening(positions)
+		if !ok || opening.ECO != line.eco || opening.Name != line.name || opening.Variation != line.variation {
+			t.Errorf("%v %v: classified as %v", line.eco, line.moves, opening)
+		}
+	}
+	if len(index) != len(ecoTable) {
+		t.Errorf("%v positions for %v lines", len(index), len(ecoTable))
+	}
+	for _, volume := range "ABCDE" {
+		for i := 0; i < 100; i++ {
+			var eco = fmt.Sprintf("%c%02d", volume, i)
+			if !codes[eco] {
+				t.Errorf("%v missing", eco)
+			}
+		}
+	}
+}
+
+func TestClassifyOpening(t *testing.T) {
+	var tests = []struct {
+		moves   string
+		opening string
+	}{
+		{"", ""},
+		{"e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 d6 c3 O-O", "C90 Ruy Lopez: Closed"},
+		// out of book moves keep the last known opening
+		{"e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 h3 e5 Nde2", "B90 Sicilian Defense: Najdorf Variation"},
+		{"e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5 e6 f4 Qb6 Qd2 Qxb2", "B97 Sicilian Defense: Najdorf Variation, Poisoned Pawn Variation"},
+		{"d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5 O-O Nc6 d5 Ne7 Ne1 Nd7 f3 f5 g4", "E99 King's Indian Defense: Orthodox Variation, Classical System, Main Line"},
+		// transpositions
+		{"Nf3 d5 d4", "D02 Queen's Pawn Game"},
+		{"c4 Nf6 Nc3 g6 d4 Bg7", "E61 King's Indian Defense"},
+		{"c4 e6 Nc3 d5 d4 Nf6", "D31 Queen's Gambit Declined: Queen's Knight Variation"},
+		{"d4 Nf6 c4 e6 Nc3 d5 Nf3", "D37 Queen's Gambit Declined: Three Knights Variation"},
+		{"Nf3 Nf6 c4 c5 Nc3 Nc6 g3 g6 Bg2 Bg7", "A38 English Opening: Symmetrical Variation, Full Symmetry Line"},
+	}
+	for _, test := range tests {
+		var positions, err = ParseMoves(initialPosition(), test.moves)
+		if err != nil {
+			t.Fatal(test.moves, err)
+		}
+		var opening, ok = ClassifyOpening(positions)
+		var s = ""
+		if ok {
+			s = opening.String()
+		}
+		if s != test.opening {
+			t.Errorf("%v: %q, expected %q", test.moves, s, test.opening)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FENField identifies a field of a FEN string
+type FENField int
+
+const (
+	FENFields FENField = iota
+	FENPlacement
+	FENSideToMove
+	FENCastling
+	FENEnPassant
+	FENHalfmoveClock
+	FENFullmoveNumber
+	FENCheckCounter
+	FENPocket
+)
+
+var fenFieldNames = [...]string{"fields", "piece placement", "side to move",
+	"castling", "en passant", "halfmove clock", "fullmove number", "check counter", "pocket"}
+
+func (f FENField) String() string {
+	return fenFieldNames[f]
+}
+
+// FENError reports an invalid FEN and the field that caused it
+type FENError struct {
+	Field  FENField
+	Value  string
+	Reason string
+}
+
+func (e *FENError) Error() string {
+	return fmt.Sprintf("invalid fen %v %q: %v", e.Field, e.Value, e.Reason)
+}
+
+func fenError(field FENField, value, format string, a ...interface{}) error {
+	return &FENError{Field: field, Value: value, Reason: fmt.Sprintf(format, a...)}
+}
+
+// NewPositionFromFEN parses and validates a FEN string.
+// The halfmove clock and fullmove number may be omitted, as in EPD.
+// Errors are of type *FENError.
+func NewPositionFromFEN(fen string) (Position, error) {
+	return parseFEN(fen, VariantStandard)
+}
+
+// parseFEN parses a FEN of the variant without the fields the variant adds
+func parseFEN(fen string, variant Variant) (Position, error) {
+	var tokens = strings.Fields(fen)
+	if len(tokens) < 4 || len(tokens) > 6 {
+		return Position{}, fenError(FENFields, fen, "expected 4 to 6 fields, got %v", len(tokens))
+	}
+
+	board, err := parsePlacement(tokens[0], variant)
+	if err != nil {
+		return Position{}, err
+	}
+
+	var whiteMove bool
+	switch tokens[1] {
+	case "w":
+		whiteMove = true
+	case "b":
+		whiteMove = false
+	default:
+		return Position{}, fenError(FENSideToMove, tokens[1], "expected w or b")
+	}
+
+	cr, err := parseCastling(tokens[2], &board)
+	if err != nil {
+		return Position{}, err
+	}
+	if cr != 0 && variant == VariantAntichess {
+		return Position{}, fenError(FENCastling, tokens[2], "no castling in %v", variant)
+	}
+
+	epSquare, err := parseEnPassant(tokens[3], &board, whiteMove)
+	if err != nil {
+		return Position{}, err
+	}
+
+	var rule50 = 0
+	if len(tokens) > 4 {
+		rule50, err = strconv.Atoi(tokens[4])
+		if err != nil || rule50 < 0 {
+			return Position{}, fenError(FENHalfmoveClock, tokens[4], "expected a non-negative number")
+		}
+	}
+
+	var fullMove = 1
+	if len(tokens) > 5 {
+		fullMove, err = strconv.Atoi(tokens[5])
+		if err != nil || fullMove < 1 {
+			return Position{}, fenError(FENFullmoveNumber, tokens[5], "expected a positive number")
+		}
+	}
+
+	var pos, isLegal = createPosition(board, variant, whiteMove, cr, epSquare, rule50, fullMove)
+	if !isLegal {
+		return Position{}, fenError(FENSideToMove, tokens[1], "side not to move is in check")
+	}
+	return pos, nil
+}
+
+// parsePlacement checks the number of pieces, antichess allows any number of kings
+// and in crazyhouse captured pieces are dropped again
+func parsePlacement(s string, variant Variant) ([64]coloredPiece, error) {
+	var board [64]coloredPiece
+	var ranks = strings.Split(s, "/")
+	if len(ranks) != 8 {
+		return board, fenError(FENPlacement, s, "expected 8 ranks, got %v", len(ranks))
+	}
+	var kings, pawns, pieces [2]int
+	for i, rank := range ranks {
+		var file = 0
+		var lastDigit = false
+		for _, ch := range rank {
+			if ch >= '1' && ch <= '8' {
+				if lastDigit {
+					return board, fenError(FENPlacement, s, "consecutive digits in rank %v", 8-i)
+				}
+				lastDigit = true
+				file += int(ch - '0')
+			} else {
+				lastDigit = false
+				var piece = parsePiece(ch)
+				if piece.Type == Empty {
+					return board, fenError(FENPlacement, s, "invalid character %q", ch)
+				}
+				if file >= 8 {
+					return board, fenError(FENPlacement, s, "rank %v has more than 8 squares", 8-i)
+				}
+				var side = 0
+				if !piece.Side {
+					side = 1
+				}
+				switch piece.Type {
+				case King:
+					kings[side]++
+				case Pawn:
+					if i == 0 || i == 7 {
+						return board, fenError(FENPlacement, s, "pawn on rank %v", 8-i)
+					}
+					pawns[side]++
+				}
+				pieces[side]++
+				board[MakeSquare(file, Rank8-i)] = piece
+				file++
+			}
+		}
+		if file != 8 {
+			return board, fenError(FENPlacement, s, "rank %v has %v squares", 8-i, file)
+		}
+	}
+	for side, name := range [2]string{"white", "black"} {
+		if kings[side] != 1 && variant != VariantAntichess {
+			return board, fenError(FENPlacement, s, "%v has %v kings", name, kings[side])
+		}
+		if variant == VariantCrazyhouse {
+			continue
+		}
+		if pawns[side] > 8 {
+			return board, fenError(FENPlacement, s, "%v has %v pawns", name, pawns[side])
+		}
+		if pieces[side] > 16 {
+			return board, fenError(FENPlacement, s, "%v has %v pieces", name, pieces[side])
+		}
+	}
+	return board, nil
+}
+
+// parseCastling checks that every castling right has its king and rook on the initial squares
+func parseCastling(s string, board *[64]coloredPiece) (int, error) {
+	if s == "-" {
+		return 0, nil
+	}
+	var rights = []struct {
+		ch     rune
+		flag   int
+		side   bool
+		kingSq int
+		rookSq int
+	}{
+		{'K', WhiteKingSide, true, SquareE1, SquareH1},
+		{'Q', WhiteQueenSide, true, SquareE1, SquareA1},
+		{'k', BlackKingSide, false, SquareE8, SquareH8},
+		{'q', BlackQueenSide, false, SquareE8, SquareA8},
+	}
+	var cr = 0
+	for _, ch := range s {
+		var found = false
+		for _, right := range rights {
+			if ch != right.ch {
+				continue
+			}
+			found = true
+			if cr&right.flag != 0 {
+				return 0, fenError(FENCastling, s, "duplicate %q", ch)
+			}
+			if board[right.kingSq] != (coloredPiece{King, right.side}) {
+				return 0, fenError(FENCastling, s, "%q without king on %v", ch, SquareName(right.kingSq))
+			}
+			if board[right.rookSq] != (coloredPiece{Rook, right.side}) {
+				return 0, fenError(FENCastling, s, "%q without rook on %v", ch, SquareName(right.rookSq))
+			}
+			cr |= right.flag
+		}
+		if !found {
+			return 0, fenError(FENCastling, s, "invalid character %q", ch)
+		}
+	}
+	return cr, nil
+}
+
+// parseEnPassant checks that the en passant square is behind a pawn that has just made a double push
+func parseEnPassant(s string, board *[64]coloredPiece, whiteMove bool) (int, error) {
+	if s == "-" {
+		return SquareNone, nil
+	}
+	var sq = ParseSquare(s)
+	if sq == SquareNone {
+		return SquareNone, fenError(FENEnPassant, s, "invalid square")
+	}
+	var rank, forward, backward = Rank3, sq + 8, sq - 8
+	if whiteMove {
+		rank, forward, backward = Rank6, sq-8, sq+8
+	}
+	if Rank(sq) != rank {
+		return SquareNone, fenError(FENEnPassant, s, "expected rank %v", rank+1)
+	}
+	if board[sq].Type != Empty || board[backward].Type != Empty {
+		return SquareNone, fenError(FENEnPassant, s, "square or square behind is occupied")
+	}
+	if board[forward] != (coloredPiece{Pawn, !whiteMove}) {
+		return SquareNone, fenError(FENEnPassant, s, "no pawn in front of the square")
+	}
+	return sq, nil
+}
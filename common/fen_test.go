@@ -0,0 +1,162 @@
+package common
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestNewPositionFromFENValid(t *testing.T) {
+	var fens = []string{
+		InitialPositionFen,
+		"rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2",
+		"rnbqkbnr/pppp1ppp/8/8/3pP3/8/PPP2PPP/RNBQKBNR b KQkq e3 0 3",
+		"rnbqkbnr/ppp2ppp/8/3pP3/8/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 3",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - -",
+		"r3k2r/8/8/8/8/8/8/R3K2R  w  Qk  -  0  1",
+	}
+	for _, fen := range fens {
+		if _, err := NewPositionFromFEN(fen); err != nil {
+			t.Errorf("%v: %v", fen, err)
+		}
+	}
+}
+
+func TestNewPositionFromFENInvalid(t *testing.T) {
+	var tests = []struct {
+		fen   string
+		field FENField
+	}{
+		{"", FENFields},
+		{"8/8/8/8/8/8/8/8 w", FENFields},
+		{InitialPositionFen + " 1", FENFields},
+		{"rnbqkbnr/pppppppp/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FENPlacement},
+		{"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FENPlacement},
+		{"rnbqkbnr/pppppppp/7/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FENPlacement},
+		{"rnbqkbnr/ppppxppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FENPlacement},
+		{"rnbqkbnr/ppppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FENPlacement},
+		{"rnbqkbnr/pppppppp/44/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FENPlacement},
+		{"rnbq1bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQ - 0 1", FENPlacement},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBKKBNR w kq - 0 1", FENPlacement},
+		{"rnbqkbnP/pppppppp/8/8/8/8/PPPPPPP1/RNBQKBNR w KQq - 0 1", FENPlacement},
+		{"4k3/8/8/8/8/8/8/p3K3 w - - 0 1", FENPlacement},
+		{InitialPositionFen[:len(InitialPositionFen)-12] + "x KQkq - 0 1", FENSideToMove},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkx - 0 1", FENCastling},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KKq - 0 1", FENCastling},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQ1BNR w K - 0 1", FENPlacement},
+		{"4k3/8/8/8/8/8/8/4K2R w KQ - 0 1", FENCastling},
+		{"4k3/8/8/8/8/8/8/R4K1R w K - 0 1", FENCastling},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1", FENEnPassant},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e6 0 1", FENEnPassant},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e 0 1", FENEnPassant},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq z9 0 1", FENEnPassant},
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e3 0 1", FENEnPassant},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - x 1", FENHalfmoveClock},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - -1 1", FENHalfmoveClock},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0", FENFullmoveNumber},
+		{"4k3/8/8/8/8/8/8/r3K3 b - - 0 1", FENSideToMove},
+		{"4k3/4R3/8/8/8/8/8/4K3 w - - 0 1", FENSideToMove},
+	}
+	for _, test := range tests {
+		var _, err = NewPositionFromFEN(test.fen)
+		var fenErr *FENError
+		if !errors.As(err, &fenErr) {
+			t.Errorf("%q: expected FENError, got %v", test.fen, err)
+			continue
+		}
+		if fenErr.Field != test.field {
+			t.Errorf("%q: expected field %v, got %v", test.fen, test.field, err)
+		}
+	}
+}
+
+func TestParseSquare(t *testing.T) {
+	var tests = []struct {
+		s  string
+		sq int
+	}{
+		{"-", SquareNone},
+		{"a1", SquareA1},
+		{"h8", SquareH8},
+		{"e", SquareNone},
+		{"", SquareNone},
+		{"i1", SquareNone},
+		{"a9", SquareNone},
+		{"a10", SquareNone},
+	}
+	for _, test := range tests {
+		if sq := ParseSquare(test.s); sq != test.sq {
+			t.Errorf("ParseSquare(%q) = %v, expected %v", test.s, sq, test.sq)
+		}
+	}
+}
+
+// fenCorpus collects positions from random games played from a few start positions
+func fenCorpus(games, plies int) []Position {
+	var starts = []string{
+		InitialPositionFen,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+		"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+	}
+	var r = rand.New(rand.NewSource(1))
+	var result []Position
+	for i := 0; i < games; i++ {
+		var p, err = NewPositionFromFEN(starts[i%len(starts)])
+		if err != nil {
+			panic(err)
+		}
+		for j := 0; j < plies; j++ {
+			result = append(result, p)
+			var ml = p.GenerateLegalMoves()
+			if len(ml) == 0 {
+				break
+			}
+			var child Position
+			p.MakeMove(ml[r.Intn(len(ml))], &child)
+			p = child
+		}
+	}
+	return result
+}
+
+func TestFENRoundTrip(t *testing.T) {
+	var games = 300
+	if testing.Short() {
+		games = 30
+	}
+	for _, p := range fenCorpus(games, 200) {
+		var fen = p.String()
+		var q, err = NewPositionFromFEN(fen)
+		if err != nil {
+			t.Fatalf("%v: %v", fen, err)
+		}
+		if s := q.String(); s != fen {
+			t.Fatalf("round trip %v -> %v", fen, s)
+		}
+		q.LastMove = p.LastMove
+		if q != p {
+			t.Fatalf("%v: parsed position differs: %+v, expected %+v", fen, q, p)
+		}
+	}
+}
+
+func TestFullMoveNumber(t *testing.T) {
+	var p, err = NewPositionFromFEN(InitialPositionFen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lan := range []string{"e2e4", "e7e5", "g1f3"} {
+		var ok bool
+		p, ok = p.MakeMoveLAN(lan)
+		if !ok {
+			t.Fatal(lan)
+		}
+	}
+	var expected = "rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2"
+	if fen := p.String(); fen != expected {
+		t.Errorf("got %v, expected %v", fen, expected)
+	}
+}
@@ -0,0 +1,133 @@
+//go:build go1.18
+// +build go1.18
+
+package common
+
+import (
+	"testing"
+)
+
+func addFuzzPositions(f *testing.F, extra ...interface{}) {
+	for i, p := range fenCorpus(6, 20) {
+		var args = append([]interface{}{p.String()}, extra...)
+		if i%7 == 0 {
+			f.Add(args...)
+		}
+	}
+}
+
+// checkPosition verifies the invariants every position accepted or produced by the package must hold
+func checkPosition(t *testing.T, p *Position) {
+	if p.Key != p.computeKey() {
+		t.Fatalf("%v: key %x, expected %x", p, p.Key, p.computeKey())
+	}
+	if p.Checkers != p.computeCheckers() {
+		t.Fatalf("%v: wrong checkers", p)
+	}
+	var buffer, legalBuffer [MaxMoves]OrderedMove
+	var child Position
+	var legal = 0
+	for _, om := range p.GenerateMoves(buffer[:]) {
+		if p.MakeMove(om.Move, &child) {
+			legal++
+		}
+	}
+	if n := len(p.GenerateLegal(legalBuffer[:])); n != legal {
+		t.Fatalf("%v: %v legal moves, expected %v", p, n, legal)
+	}
+}
+
+func FuzzNewPositionFromFEN(f *testing.F) {
+	addFuzzPositions(f)
+	f.Add("8/8/8/8/8/8/8/8 w - - 0 1")
+	f.Add("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1")
+	f.Fuzz(func(t *testing.T, fen string) {
+		var p, err = NewPositionFromFEN(fen)
+		if err != nil {
+			return
+		}
+		checkPosition(t, &p)
+		var p2, err2 = NewPositionFromFEN(p.String())
+		if err2 != nil {
+			t.Fatalf("%q: output %q rejected: %v", fen, p.String(), err2)
+		}
+		if p2 != p {
+			t.Fatalf("%q: round trip %q differs", fen, p.String())
+		}
+	})
+}
+
+func FuzzMakeMoveLAN(f *testing.F) {
+	addFuzzPositions(f, "e2e4")
+	f.Add(InitialPositionFen, "g1f3")
+	f.Add("r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", "a7b8Q")
+	f.Fuzz(func(t *testing.T, fen, lan string) {
+		var p, err = NewPositionFromFEN(fen)
+		if err != nil {
+			return
+		}
+		var child, ok = p.MakeMoveLAN(lan)
+		if !ok {
+			return
+		}
+		checkPosition(t, &child)
+		var found = false
+		for _, m := range p.GenerateLegalMoves() {
+			found = found || m == child.LastMove
+		}
+		if !found {
+			t.Fatalf("%v: %v made illegal move %v", fen, lan, child.LastMove)
+		}
+	})
+}
+
+func FuzzParseMoveSAN(f *testing.F) {
+	addFuzzPositions(f, "e4")
+	f.Add(InitialPositionFen, "Nf3")
+	f.Add("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "O-O-O")
+	f.Fuzz(func(t *testing.T, fen, san string) {
+		var p, err = NewPositionFromFEN(fen)
+		if err != nil {
+			return
+		}
+		var move = ParseMoveSAN(&p, san)
+		if move == MoveEmpty {
+			return
+		}
+		var child Position
+		if !p.IsPseudoLegal(move) || !p.MakeMove(move, &child) {
+			t.Fatalf("%v: %v parsed to illegal move %v", fen, san, move)
+		}
+		checkPosition(t, &child)
+		if ParseMoveSAN(&p, moveToSAN(&p, p.GenerateLegalMoves(), move)) != move {
+			t.Fatalf("%v: %v does not round trip", fen, san)
+		}
+	})
+}
+
+func FuzzMakeMove(f *testing.F) {
+	addFuzzPositions(f, uint32(0))
+	f.Add(InitialPositionFen, uint32(makeMove(SquareE2, SquareE4, Pawn, Empty)))
+	f.Fuzz(func(t *testing.T, fen string, data uint32) {
+		var p, err = NewPositionFromFEN(fen)
+		if err != nil {
+			return
+		}
+		var move = Move(data & (1<<21 - 1))
+		if !p.IsPseudoLegal(move) {
+			return
+		}
+		var child Position
+		var legal = p.MakeMove(move, &child)
+		var expected = false
+		for _, m := range p.GenerateLegalMoves() {
+			expected = expected || m == move
+		}
+		if legal != expected {
+			t.Fatalf("%v: move %v legal %v, expected %v", fen, move, legal, expected)
+		}
+		if legal {
+			checkPosition(t, &child)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package common
+
+// GenerateLegal generates legal moves only, in the same order as GenerateMoves.
+// Pinned pieces move along the pin ray, in check only evasions are generated,
+// so no move has to be made to test its legality.
+// A game ended by a rule of the variant has no moves,
+// atomic moves are made to test them as explosions change the board.
+func (p *Position) GenerateLegal(ml []OrderedMove) []OrderedMove {
+	if p.isVariantEnd() {
+		return ml[:0]
+	}
+	if p.Variant == VariantAntichess {
+		return p.generateAntichess(ml)
+	}
+	if p.Variant == VariantAtomic {
+		return p.generateAtomicLegal(ml)
+	}
+	var count = 0
+	var fromBB, toBB, ownPieces, oppPieces uint64
+	var from, to int
+
+	if p.WhiteMove {
+		ownPieces = p.White
+		oppPieces = p.Black
+	} else {
+		ownPieces = p.Black
+		oppPieces = p.White
+	}
+
+	var allPieces = p.White | p.Black
+	var kingSq = FirstOne(p.Kings & ownPieces)
+
+	var target = ^ownPieces
+	if p.Checkers != 0 {
+		if MoreThanOne(p.Checkers) {
+			target = 0
+		} else {
+			target = p.Checkers | betweenMask[FirstOne(p.Checkers)][kingSq]
+		}
+	}
+
+	// pinRays[sq] is the ray a pinned piece on sq can move along, including the pinner
+	var pinned uint64
+	var pinRays [64]uint64
+	var snipers = oppPieces & ((RookAttacks(kingSq, 0) & (p.Rooks | p.Queens)) |
+		(BishopAttacks(kingSq, 0) & (p.Bishops | p.Queens)))
+	for ; snipers != 0; snipers &= snipers - 1 {
+		var sniper = FirstOne(snipers)
+		var blockers = betweenMask[kingSq][sniper] & allPieces
+		if blockers != 0 && !MoreThanOne(blockers) && (blockers&ownPieces) != 0 {
+			pinned |= blockers
+			pinRays[FirstOne(blockers)] = betweenMask[kingSq][sniper] | SquareMask[sniper]
+		}
+	}
+
+	if target != 0 {
+		var ownPawns = p.Pawns & ownPieces
+
+		if p.EpSquare != SquareNone {
+			for fromBB = PawnAttacks(p.EpSquare, !p.WhiteMove) & ownPawns; fromBB != 0; fromBB &= fromBB - 1 {
+				from = FirstOne(fromBB)
+				if p.isLegalEnPassant(from, kingSq, oppPieces) {
+					ml[count].Move = makeMove(from, p.EpSquare, Pawn, Pawn)
+					count++
+				}
+			}
+		}
+
+		var forward, left, right = 8, 7, 9
+		var promotionRank = Rank7Mask
+		var doublePushRank = Rank2
+		if !p.WhiteMove {
+			forward, left, right = -8, -9, -7
+			promotionRank = Rank2Mask
+			doublePushRank = Rank7
+		}
+
+		for fromBB = ownPawns; fromBB != 0; fromBB &= fromBB - 1 {
+			from = FirstOne(fromBB)
+			if (SquareMask[from] & promotionRank) != 0 {
+				continue
+			}
+			var legalTo = target
+			if (pinned & SquareMask[from]) != 0 {
+				legalTo &= pinRays[from]
+			}
+			if (SquareMask[from+forward] & allPieces) == 0 {
+				if (SquareMask[from+forward] & legalTo) != 0 {
+					ml[count].Move = makeMove(from, from+forward, Pawn, Empty)
+					count++
+				}
+				if Rank(from) == doublePushRank &&
+					(SquareMask[from+2*forward]&allPieces) == 0 &&
+					(SquareMask[from+2*forward]&legalTo) != 0 {
+					ml[count].Move = makeMove(from, from+2*forward, Pawn, Empty)
+					count++
+				}
+			}
+			if File(from) > FileA && (SquareMask[from+left]&oppPieces&legalTo) != 0 {
+				ml[count].Move = makeMove(from, from+left, Pawn, p.WhatPiece(from+left))
+				count++
+			}
+			if File(from) < FileH && (SquareMask[from+right]&oppPieces&legalTo) != 0 {
+				ml[count].Move = makeMove(from, from+right, Pawn, p.WhatPiece(from+right))
+				count++
+			}
+		}
+		for fromBB = ownPawns & promotionRank; fromBB != 0; fromBB &= fromBB - 1 {
+			from = FirstOne(fromBB)
+			var legalTo = target
+			if (pinned & SquareMask[from]) != 0 {
+				legalTo &= pinRays[from]
+			}
+			if (SquareMask[from+forward]&allPieces) == 0 && (SquareMask[from+forward]&legalTo) != 0 {
+				count += addPromotions(ml[count:], makeMove(from, from+forward, Pawn, Empty))
+			}
+			if File(from) > FileA && (SquareMask[from+left]&oppPieces&legalTo) != 0 {
+				count += addPromotions(ml[count:], makeMove(from, from+left, Pawn, p.WhatPiece(from+left)))
+			}
+			if File(from) < FileH && (SquareMask[from+right]&oppPieces&legalTo) != 0 {
+				count += addPromotions(ml[count:], makeMove(from, from+right, Pawn, p.WhatPiece(from+right)))
+			}
+		}
+
+		// a pinned knight can not move
+		for fromBB = p.Knights & ownPieces &^ pinned; fromBB != 0; fromBB &= fromBB - 1 {
+			from = FirstOne(fromBB)
+			for toBB = KnightAttacks[from] & target; toBB != 0; toBB &= toBB - 1 {
+				to = FirstOne(toBB)
+				ml[count].Move = makeMove(from, to, Knight, p.WhatPiece(to))
+				count++
+			}
+		}
+
+		for fromBB = p.Bishops & ownPieces; fromBB != 0; fromBB &= fromBB - 1 {
+			from = FirstOne(fromBB)
+			for toBB = BishopAttacks(from, allPieces) & target & pinRay(pinned, &pinRays, from); toBB != 0; toBB &= toBB - 1 {
+				to = FirstOne(toBB)
+				ml[count].Move = makeMove(from, to, Bishop, p.WhatPiece(to))
+				count++
+			}
+		}
+
+		for fromBB = p.Rooks & ownPieces; fromBB != 0; fromBB &= fromBB - 1 {
+			from = FirstOne(fromBB)
+			for toBB = RookAttacks(from, allPieces) & target & pinRay(pinned, &pinRays, from); toBB != 0; toBB &= toBB - 1 {
+				to = FirstOne(toBB)
+				ml[count].Move = makeMove(from, to, Rook, p.WhatPiece(to))
+				count++
+			}
+		}
+
+		for fromBB = p.Queens & ownPieces; fromBB != 0; fromBB &= fromBB - 1 {
+			from = FirstOne(fromBB)
+			for toBB = QueenAttacks(from, allPieces) & target & pinRay(pinned, &pinRays, from); toBB != 0; toBB &= toBB - 1 {
+				to = FirstOne(toBB)
+				ml[count].Move = makeMove(from, to, Queen, p.WhatPiece(to))
+				count++
+			}
+		}
+	}
+
+	// the king must not stay on a line attacked through its own square
+	var occWithoutKing = allPieces &^ SquareMask[kingSq]
+	for toBB = KingAttacks[kingSq] &^ ownPieces; toBB != 0; toBB &= toBB - 1 {
+		to = FirstOne(toBB)
+		if !p.isAttackedByOcc(to, oppPieces, occWithoutKing) {
+			ml[count].Move = makeMove(kingSq, to, King, p.WhatPiece(to))
+			count++
+		}
+	}
+
+	if p.Checkers == 0 {
+		if p.WhiteMove {
+			if (p.CastleRights&WhiteKingSide) != 0 &&
+				(allPieces&f1g1Mask) == 0 &&
+				!p.IsAttackedBySide(SquareF1, false) &&
+				!p.IsAttackedBySide(SquareG1, false) {
+				ml[count].Move = whiteKingSideCastle
+				count++
+			}
+			if (p.CastleRights&WhiteQueenSide) != 0 &&
+				(allPieces&b1d1Mask) == 0 &&
+				!p.IsAttackedBySide(SquareD1, false) &&
+				!p.IsAttackedBySide(SquareC1, false) {
+				ml[count].Move = whiteQueenSideCastle
+				count++
+			}
+		} else {
+			if (p.CastleRights&BlackKingSide) != 0 &&
+				(allPieces&f8g8Mask) == 0 &&
+				!p.IsAttackedBySide(SquareF8, true) &&
+				!p.IsAttackedBySide(SquareG8, true) {
+				ml[count].Move = blackKingSideCastle
+				count++
+			}
+			if (p.CastleRights&BlackQueenSide) != 0 &&
+				(allPieces&b8d8Mask) == 0 &&
+				!p.IsAttackedBySide(SquareD8, true) &&
+				!p.IsAttackedBySide(SquareC8, true) {
+				ml[count].Move = blackQueenSideCastle
+				count++
+			}
+		}
+	}
+
+	// a drop can block a check but not capture the checker
+	if p.Variant == VariantCrazyhouse {
+		count += p.generateDrops(ml[count:], target&^allPieces)
+	}
+
+	return ml[:count]
+}
+
+func pinRay(pinned uint64, pinRays *[64]uint64, sq int) uint64 {
+	if (pinned & SquareMask[sq]) != 0 {
+		return pinRays[sq]
+	}
+	return ^uint64(0)
+}
+
+// isLegalEnPassant checks the king after removing both pawns from the board,
+// this also covers the pawns leaving a rank shared by the king and an enemy rook.
+func (p *Position) isLegalEnPassant(from, kingSq int, oppPieces uint64) bool {
+	var capturedSq = p.EpSquare - 8
+	if !p.WhiteMove {
+		capturedSq = p.EpSquare + 8
+	}
+	var occ = (p.White|p.Black)&^SquareMask[from]&^SquareMask[capturedSq] | SquareMask[p.EpSquare]
+	var enemy = oppPieces &^ SquareMask[capturedSq]
+	if (p.Checkers & (p.Knights | p.Pawns) &^ SquareMask[capturedSq]) != 0 {
+		return false
+	}
+	return (RookAttacks(kingSq, occ)&(p.Rooks|p.Queens)&enemy) == 0 &&
+		(BishopAttacks(kingSq, occ)&(p.Bishops|p.Queens)&enemy) == 0
+}
+
+// isAttackedByOcc reports whether enemy pieces attack sq with the given occupancy
+func (p *Position) isAttackedByOcc(sq int, enemy, occ uint64) bool {
+	return (PawnAttacks(sq, (enemy&p.White) == 0)&p.Pawns&enemy) != 0 ||
+		(KnightAttacks[sq]&p.Knights&enemy) != 0 ||
+		(KingAttacks[sq]&p.Kings&enemy) != 0 ||
+		(BishopAttacks(sq, occ)&(p.Bishops|p.Queens)&enemy) != 0 ||
+		(RookAttacks(sq, occ)&(p.Rooks|p.Queens)&enemy) != 0
+}
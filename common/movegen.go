@@ -17,18 +17,20 @@ var (
 func (p *Position) GenerateLegalMoves() []Move {
 	var result []Move
 	var buffer [MaxMoves]OrderedMove
-	var child Position
-	var ml = p.GenerateMoves(buffer[:])
+	var ml = p.GenerateLegal(buffer[:])
 	for i := range ml {
-		var m = ml[i].Move
-		if p.MakeMove(m, &child) {
-			result = append(result, m)
-		}
+		result = append(result, ml[i].Move)
 	}
 	return result
 }
 
 func (p *Position) GenerateMoves(ml []OrderedMove) []OrderedMove {
+	if p.isVariantEnd() {
+		return ml[:0]
+	}
+	if p.Variant == VariantAntichess {
+		return p.generateAntichess(ml)
+	}
 	var count = 0
 	var fromBB, toBB, ownPieces, oppPieces uint64
 	var from, to int
@@ -41,8 +43,9 @@ func (p *Position) GenerateMoves(ml []OrderedMove) []OrderedMove {
 		oppPieces = p.White
 	}
 
+	// in atomic a check is also answered by an explosion
 	var target = ^ownPieces
-	if p.Checkers != 0 {
+	if p.Checkers != 0 && p.Variant != VariantAtomic {
 		var kingSq = FirstOne(p.Kings & ownPieces)
 		target = p.Checkers | betweenMask[FirstOne(p.Checkers)][kingSq]
 	}
@@ -161,8 +164,13 @@ func (p *Position) GenerateMoves(ml []OrderedMove) []OrderedMove {
 	}
 
 	{
+		// an atomic king can not capture
+		var kingTarget = ^ownPieces
+		if p.Variant == VariantAtomic {
+			kingTarget = ^allPieces
+		}
 		from = FirstOne(p.Kings & ownPieces)
-		for toBB = KingAttacks[from] &^ ownPieces; toBB != 0; toBB &= toBB - 1 {
+		for toBB = KingAttacks[from] & kingTarget; toBB != 0; toBB &= toBB - 1 {
 			to = FirstOne(toBB)
 			ml[count].Move = makeMove(from, to, King, p.WhatPiece(to))
 			count++
@@ -171,40 +179,50 @@ func (p *Position) GenerateMoves(ml []OrderedMove) []OrderedMove {
 		if p.WhiteMove {
 			if (p.CastleRights&WhiteKingSide) != 0 &&
 				(allPieces&f1g1Mask) == 0 &&
-				!p.isAttackedBySide(SquareE1, false) &&
-				!p.isAttackedBySide(SquareF1, false) {
+				!p.isCheckedSquare(SquareE1, false) &&
+				!p.isCheckedSquare(SquareF1, false) {
 				ml[count].Move = whiteKingSideCastle
 				count++
 			}
 			if (p.CastleRights&WhiteQueenSide) != 0 &&
 				(allPieces&b1d1Mask) == 0 &&
-				!p.isAttackedBySide(SquareE1, false) &&
-				!p.isAttackedBySide(SquareD1, false) {
+				!p.isCheckedSquare(SquareE1, false) &&
+				!p.isCheckedSquare(SquareD1, false) {
 				ml[count].Move = whiteQueenSideCastle
 				count++
 			}
 		} else {
 			if (p.CastleRights&BlackKingSide) != 0 &&
 				(allPieces&f8g8Mask) == 0 &&
-				!p.isAttackedBySide(SquareE8, true) &&
-				!p.isAttackedBySide(SquareF8, true) {
+				!p.isCheckedSquare(SquareE8, true) &&
+				!p.isCheckedSquare(SquareF8, true) {
 				ml[count].Move = blackKingSideCastle
 				count++
 			}
 			if (p.CastleRights&BlackQueenSide) != 0 &&
 				(allPieces&b8d8Mask) == 0 &&
-				!p.isAttackedBySide(SquareE8, true) &&
-				!p.isAttackedBySide(SquareD8, true) {
+				!p.isCheckedSquare(SquareE8, true) &&
+				!p.isCheckedSquare(SquareD8, true) {
 				ml[count].Move = blackQueenSideCastle
 				count++
 			}
 		}
 	}
 
+	if p.Variant == VariantCrazyhouse {
+		count += p.generateDrops(ml[count:], target&^allPieces)
+	}
+
 	return ml[:count]
 }
 
 func (p *Position) GenerateCaptures(ml []OrderedMove) []OrderedMove {
+	if p.isVariantEnd() {
+		return ml[:0]
+	}
+	if p.Variant == VariantAntichess {
+		return p.generateAntichessCaptures(ml)
+	}
 	var count = 0
 	var fromBB, toBB, ownPieces, oppPieces uint64
 	var from, to, promotion int
@@ -303,7 +321,7 @@ func (p *Position) GenerateCaptures(ml []OrderedMove) []OrderedMove {
 		}
 	}
 
-	{
+	if p.Variant != VariantAtomic {
 		from = FirstOne(p.Kings & ownPieces)
 		for toBB = KingAttacks[from] & target; toBB != 0; toBB &= toBB - 1 {
 			to = FirstOne(toBB)
@@ -322,3 +340,108 @@ func addPromotions(ml []OrderedMove, move Move) (count int) {
 	ml[3].Move = move ^ Move(Knight<<18)
 	return 4
 }
+
+// IsPseudoLegal reports whether move can be made in the position with MakeMove.
+// It validates moves that were not generated for the position, like moves from the transposition table.
+// In check a move may be accepted that does not evade the check, MakeMove rejects it.
+func (p *Position) IsPseudoLegal(move Move) bool {
+	if move == MoveEmpty || move>>21 != 0 {
+		return false
+	}
+	if p.Variant == VariantAntichess {
+		return p.isAntichessMove(move)
+	}
+	if move.IsDrop() {
+		return p.isPseudoLegalDrop(move)
+	}
+	var from = move.From()
+	var to = move.To()
+	var movingPiece = move.MovingPiece()
+	var capturedPiece = move.CapturedPiece()
+	var promotion = move.Promotion()
+
+	var ownPieces, oppPieces = p.Black, p.White
+	if p.WhiteMove {
+		ownPieces, oppPieces = p.White, p.Black
+	}
+	var allPieces = p.White | p.Black
+	if (SquareMask[from]&ownPieces) == 0 || p.WhatPiece(from) != movingPiece {
+		return false
+	}
+
+	if movingPiece == Pawn {
+		var lastRank = let(p.WhiteMove, Rank8, Rank1)
+		if (Rank(to) == lastRank) != (promotion != Empty) ||
+			promotion == Pawn || promotion == King {
+			return false
+		}
+		if capturedPiece == Pawn && to == p.EpSquare {
+			return (PawnAttacks(from, p.WhiteMove) & SquareMask[to]) != 0
+		}
+		if capturedPiece != Empty {
+			return (PawnAttacks(from, p.WhiteMove)&SquareMask[to]&oppPieces) != 0 &&
+				p.WhatPiece(to) == capturedPiece
+		}
+		if (SquareMask[to] & allPieces) != 0 {
+			return false
+		}
+		var forward = let(p.WhiteMove, 8, -8)
+		if to == from+forward {
+			return true
+		}
+		return to == from+2*forward &&
+			Rank(from) == let(p.WhiteMove, Rank2, Rank7) &&
+			(SquareMask[from+forward]&allPieces) == 0
+	}
+
+	if promotion != Empty {
+		return false
+	}
+	if capturedPiece == Empty {
+		if (SquareMask[to] & allPieces) != 0 {
+			return false
+		}
+	} else if (SquareMask[to]&oppPieces) == 0 || p.WhatPiece(to) != capturedPiece {
+		return false
+	}
+
+	var attacks uint64
+	switch movingPiece {
+	case Knight:
+		attacks = KnightAttacks[from]
+	case Bishop:
+		attacks = BishopAttacks(from, allPieces)
+	case Rook:
+		attacks = RookAttacks(from, allPieces)
+	case Queen:
+		attacks = QueenAttacks(from, allPieces)
+	case King:
+		if capturedPiece != Empty && p.Variant == VariantAtomic {
+			return false
+		}
+		attacks = KingAttacks[from]
+		switch move {
+		case whiteKingSideCastle:
+			return p.WhiteMove && (p.CastleRights&WhiteKingSide) != 0 &&
+				(allPieces&f1g1Mask) == 0 &&
+				!p.isCheckedSquare(SquareE1, false) &&
+				!p.isCheckedSquare(SquareF1, false)
+		case whiteQueenSideCastle:
+			return p.WhiteMove && (p.CastleRights&WhiteQueenSide) != 0 &&
+				(allPieces&b1d1Mask) == 0 &&
+				!p.isCheckedSquare(SquareE1, false) &&
+				!p.isCheckedSquare(SquareD1, false)
+		case blackKingSideCastle:
+			return !p.WhiteMove && (p.CastleRights&BlackKingSide) != 0 &&
+				(allPieces&f8g8Mask) == 0 &&
+				!p.isCheckedSquare(SquareE8, true) &&
+				!p.isCheckedSquare(SquareF8, true)
+		case blackQueenSideCastle:
+			return !p.WhiteMove && (p.CastleRights&BlackQueenSide) != 0 &&
+				(allPieces&b8d8Mask) == 0 &&
+				!p.isCheckedSquare(SquareE8, true) &&
+				!p.isCheckedSquare(SquareD8, true)
+		}
+	}
+	return (attacks & SquareMask[to]) != 0
+}
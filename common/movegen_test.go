@@ -0,0 +1,66 @@
+package common
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIsPseudoLegal(t *testing.T) {
+	var corpus = fenCorpus(20, 100)
+	// moves of all positions of the corpus are candidates for every position
+	var candidates = make(map[Move]bool)
+	var buffer [MaxMoves]OrderedMove
+	var r = rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		candidates[Move(r.Intn(1<<21))] = true
+	}
+	for i := range corpus {
+		for _, om := range corpus[i].GenerateMoves(buffer[:]) {
+			candidates[om.Move] = true
+		}
+	}
+	for i := range corpus {
+		var p = &corpus[i]
+		var legal = make(map[Move]bool)
+		for _, m := range p.GenerateLegalMoves() {
+			legal[m] = true
+		}
+		for _, om := range p.GenerateMoves(buffer[:]) {
+			if !p.IsPseudoLegal(om.Move) {
+				t.Fatalf("%v: generated move %v is not pseudo legal", p.String(), om.Move)
+			}
+		}
+		for m := range candidates {
+			var child Position
+			var ok = p.IsPseudoLegal(m) && p.MakeMove(m, &child)
+			if ok != legal[m] {
+				t.Fatalf("%v: move %v legal %v, expected %v", p.String(), m, ok, legal[m])
+			}
+		}
+	}
+}
+
+func TestGenerateLegal(t *testing.T) {
+	var corpus = fenCorpus(50, 150)
+	corpus = append(corpus, perftPositions(t)...)
+	var buffer, legalBuffer [MaxMoves]OrderedMove
+	var child Position
+	for i := range corpus {
+		var p = &corpus[i]
+		var expected []Move
+		for _, om := range p.GenerateMoves(buffer[:]) {
+			if p.MakeMove(om.Move, &child) {
+				expected = append(expected, om.Move)
+			}
+		}
+		var ml = p.GenerateLegal(legalBuffer[:])
+		if len(ml) != len(expected) {
+			t.Fatalf("%v: %v legal moves, expected %v", p.String(), len(ml), len(expected))
+		}
+		for j := range ml {
+			if ml[j].Move != expected[j] {
+				t.Fatalf("%v: move %v is %v, expected %v", p.String(), j, ml[j].Move, expected[j])
+			}
+		}
+	}
+}
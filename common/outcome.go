@@ -0,0 +1,211 @@
+package common
+
+// Termination is the reason a game is over
+type Termination int
+
+const (
+	TerminationNone Termination = iota
+	Checkmate
+	Stalemate
+	InsufficientMaterial
+	SeventyFiveMoveRule
+	FivefoldRepetition
+	FiftyMoveRule
+	ThreefoldRepetition
+	ThirdCheck
+	KingOnTheHill
+	AllPiecesLost
+	KingExploded
+)
+
+const (
+	darkSquares  = uint64(0xAA55AA55AA55AA55)
+	lightSquares = ^darkSquares
+)
+
+// Outcome of a game, Result is "1-0", "0-1", "1/2-1/2" or "*" for a game in progress
+type Outcome struct {
+	Termination Termination
+	Result      string
+}
+
+func (o Outcome) IsOver() bool {
+	return o.Termination != TerminationNone
+}
+
+// String describes the outcome as a PGN comment would
+func (o Outcome) String() string {
+	switch o.Termination {
+	case Checkmate:
+		if o.Result == "1-0" {
+			return "White mates"
+		}
+		return "Black mates"
+	case Stalemate:
+		if o.Result != "1/2-1/2" {
+			return o.winner() + " wins by stalemate"
+		}
+		return "Stalemate"
+	case InsufficientMaterial:
+		return "Draw by insufficient material"
+	case SeventyFiveMoveRule:
+		return "Draw by 75-move rule"
+	case FivefoldRepetition:
+		return "Draw by fivefold repetition"
+	case FiftyMoveRule:
+		return "Draw by 50-move rule"
+	case ThreefoldRepetition:
+		return "Draw by threefold repetition"
+	case ThirdCheck:
+		return o.winner() + " wins by the third check"
+	case KingOnTheHill:
+		return o.winner() + " king reaches the hill"
+	case AllPiecesLost:
+		return o.winner() + " wins by losing all pieces"
+	case KingExploded:
+		return o.winner() + " wins by exploding the king"
+	}
+	return "Game in progress"
+}
+
+func (o Outcome) winner() string {
+	if o.Result == "1-0" {
+		return "White"
+	}
+	return "Black"
+}
+
+// GameOutcome returns the outcome of a game, positions[0] is the start position.
+// Threefold repetition and the 50-move rule end the game only with claimDraw,
+// the other rules end it automatically.
+func GameOutcome(positions []Position, claimDraw bool) Outcome {
+	var p = &positions[len(positions)-1]
+	if ended, result := p.VariantEnd(); ended {
+		return variantOutcome(p, result)
+	}
+	var buffer [MaxMoves]OrderedMove
+	if len(p.GenerateLegal(buffer[:])) == 0 {
+		var termination = Stalemate
+		if p.IsCheck() {
+			termination = Checkmate
+		}
+		if result := p.NoMovesResult(); result != 0 {
+			return decisiveOutcome(termination, result > 0 == p.WhiteMove)
+		}
+		return Outcome{Stalemate, "1/2-1/2"}
+	}
+	if p.IsInsufficientMaterial() {
+		return Outcome{InsufficientMaterial, "1/2-1/2"}
+	}
+	if p.Rule50 >= 150 {
+		return Outcome{SeventyFiveMoveRule, "1/2-1/2"}
+	}
+	var repetitions = RepetitionCount(positions)
+	if repetitions >= 5 {
+		return Outcome{FivefoldRepetition, "1/2-1/2"}
+	}
+	if claimDraw {
+		if p.Rule50 >= 100 {
+			return Outcome{FiftyMoveRule, "1/2-1/2"}
+		}
+		if repetitions >= 3 {
+			return Outcome{ThreefoldRepetition, "1/2-1/2"}
+		}
+	}
+	return Outcome{TerminationNone, "*"}
+}
+
+var variantTerminations = [...]Termination{
+	VariantThreeCheck:    ThirdCheck,
+	VariantKingOfTheHill: KingOnTheHill,
+	VariantAntichess:     AllPiecesLost,
+	VariantAtomic:        KingExploded,
+}
+
+// variantOutcome converts the result of VariantEnd
+func variantOutcome(p *Position, result int) Outcome {
+	var termination = variantTerminations[p.Variant]
+	if result == 0 {
+		return Outcome{termination, "1/2-1/2"}
+	}
+	return decisiveOutcome(termination, result > 0 == p.WhiteMove)
+}
+
+func decisiveOutcome(termination Termination, whiteWins bool) Outcome {
+	if whiteWins {
+		return Outcome{termination, "1-0"}
+	}
+	return Outcome{termination, "0-1"}
+}
+
+// RepetitionCount returns how many times the last position occurred in the game.
+// Positions are the same when the same moves are possible,
+// so an en passant square without a legal capture is ignored.
+func RepetitionCount(positions []Position) int {
+	var last = len(positions) - 1
+	var key = repetitionKey(&positions[last])
+	var count = 1
+	for i := last - 2; i >= 0; i -= 2 {
+		if positions[i+1].Rule50 == 0 || positions[i+2].Rule50 == 0 {
+			break
+		}
+		if repetitionKey(&positions[i]) == key {
+			count++
+		}
+	}
+	return count
+}
+
+func repetitionKey(p *Position) uint64 {
+	if p.EpSquare == SquareNone {
+		return p.Key
+	}
+	var buffer [MaxMoves]OrderedMove
+	for _, om := range p.GenerateLegal(buffer[:]) {
+		if om.Move.To() == p.EpSquare && om.Move.MovingPiece() == Pawn {
+			return p.Key
+		}
+	}
+	return p.Key ^ enpassantKey[File(p.EpSquare)]
+}
+
+// IsInsufficientMaterial reports whether neither side can ever checkmate,
+// following the FIDE dead position rule for the material on the board.
+// In three-check only bare kings are a draw, a king can always reach the hill
+// and antichess is never drawn by material.
+// Captured pieces return in crazyhouse, only bare kings with empty pockets are a draw.
+// A single minor piece can neither mate nor explode a king in atomic.
+func (p *Position) IsInsufficientMaterial() bool {
+	switch p.Variant {
+	case VariantCrazyhouse:
+		return (p.White|p.Black) == p.Kings && p.Pockets == [2][King]uint8{}
+	case VariantThreeCheck:
+		return (p.White | p.Black) == p.Kings
+	case VariantKingOfTheHill, VariantAntichess:
+		return false
+	case VariantAtomic:
+		return PopCount((p.White|p.Black)&^p.Kings) <= 1 && (p.Pawns|p.Rooks|p.Queens) == 0
+	}
+	return p.hasInsufficientMaterial(true) && p.hasInsufficientMaterial(false)
+}
+
+// hasInsufficientMaterial reports whether the side can not checkmate with any sequence of moves
+func (p *Position) hasInsufficientMaterial(white bool) bool {
+	var own, opp = p.White, p.Black
+	if !white {
+		own, opp = p.Black, p.White
+	}
+	if (own & (p.Pawns | p.Rooks | p.Queens)) != 0 {
+		return false
+	}
+	if (own & p.Knights) != 0 {
+		// a lone knight mates only with help of enemy pieces blocking the king
+		return PopCount(own) <= 2 && (opp&^p.Kings) == 0
+	}
+	if (own & p.Bishops) != 0 {
+		// bishops of one color can not mate if no other piece can block or be captured
+		var sameColor = (p.Bishops&darkSquares) == 0 || (p.Bishops&lightSquares) == 0
+		return sameColor && p.Pawns == 0 && p.Knights == 0
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestGameOutcome(t *testing.T) {
+	var tests = []struct {
+		fen         string
+		claimDraw   bool
+		termination Termination
+		result      string
+	}{
+		{InitialPositionFen, true, TerminationNone, "*"},
+		{"rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3", false, Checkmate, "0-1"},
+		{"R5k1/5ppp/8/8/8/8/8/6K1 b - - 150 100", false, Checkmate, "1-0"},
+		{"k7/8/1Q6/8/8/8/8/7K b - - 0 1", false, Stalemate, "1/2-1/2"},
+		{"8/8/4k3/8/8/2K5/8/8 w - - 0 1", false, InsufficientMaterial, "1/2-1/2"},
+		{"8/8/4k3/8/8/2KN4/8/8 w - - 0 1", false, InsufficientMaterial, "1/2-1/2"},
+		{"8/8/4k3/8/8/2KB4/8/8 w - - 0 1", false, InsufficientMaterial, "1/2-1/2"},
+		{"8/8/2b1k3/8/4B3/2K5/8/8 w - - 0 1", false, InsufficientMaterial, "1/2-1/2"},
+		{"8/8/2b1k3/8/8/2K1B3/8/8 w - - 0 1", false, TerminationNone, "*"},
+		{"8/8/2n1k3/8/8/2KN4/8/8 w - - 0 1", false, TerminationNone, "*"},
+		{"8/8/4k3/8/8/2KNN3/8/8 w - - 0 1", false, TerminationNone, "*"},
+		{"8/8/4k3/8/8/2K5/R7/8 w - - 150 100", false, SeventyFiveMoveRule, "1/2-1/2"},
+		{"8/8/4k3/8/8/2K5/R7/8 w - - 100 100", false, TerminationNone, "*"},
+		{"8/8/4k3/8/8/2K5/R7/8 w - - 100 100", true, FiftyMoveRule, "1/2-1/2"},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(test.fen, err)
+		}
+		var outcome = GameOutcome([]Position{p}, test.claimDraw)
+		if outcome.Termination != test.termination || outcome.Result != test.result {
+			t.Errorf("%v: %v %v, expected %v %v", test.fen, outcome, outcome.Result, test.termination, test.result)
+		}
+	}
+}
+
+func TestRepetition(t *testing.T) {
+	var p, err = NewPositionFromFEN(InitialPositionFen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var positions = []Position{p}
+	var play = func(moves ...string) {
+		for _, lan := range moves {
+			var child, ok = positions[len(positions)-1].MakeMoveLAN(lan)
+			if !ok {
+				t.Fatal(lan)
+			}
+			positions = append(positions, child)
+		}
+	}
+	var shuffle = []string{"g1f3", "g8f6", "f3g1", "f6g8"}
+
+	play(shuffle...)
+	if n := RepetitionCount(positions); n != 2 {
+		t.Error(n)
+	}
+	play(shuffle...)
+	if outcome := GameOutcome(positions, false); outcome.IsOver() {
+		t.Error(outcome)
+	}
+	if outcome := GameOutcome(positions, true); outcome.Termination != ThreefoldRepetition {
+		t.Error(outcome)
+	}
+	play(shuffle...)
+	play(shuffle...)
+	if outcome := GameOutcome(positions, false); outcome.Termination != FivefoldRepetition {
+		t.Error(outcome)
+	}
+
+	// a pawn move resets the count
+	play("e2e4", "e7e5")
+	play(shuffle...)
+	if n := RepetitionCount(positions); n != 2 {
+		t.Error(n)
+	}
+}
+
+func TestRepetitionEnPassant(t *testing.T) {
+	// the en passant square after e4 gives no capture, so it is the position reached from the FEN
+	var start, _ = NewPositionFromFEN(InitialPositionFen)
+	var afterE4, _ = start.MakeMoveLAN("e2e4")
+	var fromFEN, err = NewPositionFromFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repetitionKey(&afterE4) != repetitionKey(&fromFEN) {
+		t.Error("en passant square without capture")
+	}
+
+	var capture, _ = NewPositionFromFEN("rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1")
+	var noCapture, _ = NewPositionFromFEN("rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1")
+	if repetitionKey(&capture) == repetitionKey(&noCapture) {
+		t.Error("en passant square with capture")
+	}
+}
+
+func TestInsufficientMaterialKnight(t *testing.T) {
+	// a queen can block her own king too: 6qk/8/6K1 after Nf7 is mate
+	var p, err = NewPositionFromFEN("6qk/8/6K1/8/8/3N4/8/8 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.hasInsufficientMaterial(true) {
+		t.Error("knight against queen has no mate")
+	}
+}
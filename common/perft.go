@@ -0,0 +1,251 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Perft counts leaf nodes with pseudo-legal generation and MakeMove as legality test.
+func Perft(p *Position, depth int) int {
+	var result = 0
+	var buffer [MaxMoves]OrderedMove
+	var child Position
+	var ml = p.GenerateMoves(buffer[:])
+	for i := range ml {
+		var move = ml[i].Move
+		if p.MakeMove(move, &child) {
+			if depth > 1 {
+				result += Perft(&child, depth-1)
+			} else {
+				result++
+			}
+		}
+	}
+	return result
+}
+
+// LegalPerft counts leaf nodes with the legal generator,
+// at the last ply the moves are counted without being made.
+func LegalPerft(p *Position, depth int) int {
+	if depth <= 0 {
+		return 1
+	}
+	var buffer [MaxMoves]OrderedMove
+	var ml = p.GenerateLegal(buffer[:])
+	if depth == 1 {
+		return len(ml)
+	}
+	var result = 0
+	var child Position
+	for i := range ml {
+		p.MakeMove(ml[i].Move, &child)
+		result += LegalPerft(&child, depth-1)
+	}
+	return result
+}
+
+// PerftStats counts the leaf nodes and the kinds of moves leading to them
+// as in the tables of https://www.chessprogramming.org/Perft_Results
+type PerftStats struct {
+	Nodes      int
+	Captures   int
+	EnPassant  int
+	Castles    int
+	Promotions int
+	Checks     int
+	Checkmates int
+}
+
+func (s *PerftStats) Add(other PerftStats) {
+	s.Nodes += other.Nodes
+	s.Captures += other.Captures
+	s.EnPassant += other.EnPassant
+	s.Castles += other.Castles
+	s.Promotions += other.Promotions
+	s.Checks += other.Checks
+	s.Checkmates += other.Checkmates
+}
+
+func (s PerftStats) String() string {
+	return fmt.Sprintf("nodes %v captures %v ep %v castles %v promotions %v checks %v checkmates %v",
+		s.Nodes, s.Captures, s.EnPassant, s.Castles, s.Promotions, s.Checks, s.Checkmates)
+}
+
+// PerftWithStats is LegalPerft collecting PerftStats, every leaf move has to be made.
+func PerftWithStats(p *Position, depth int) PerftStats {
+	var stats PerftStats
+	if depth <= 0 {
+		stats.Nodes = 1
+		return stats
+	}
+	perftStats(p, depth, &stats)
+	return stats
+}
+
+func perftStats(p *Position, depth int, stats *PerftStats) {
+	var buffer [MaxMoves]OrderedMove
+	var child Position
+	for _, om := range p.GenerateLegal(buffer[:]) {
+		p.MakeMove(om.Move, &child)
+		if depth > 1 {
+			perftStats(&child, depth-1, stats)
+		} else {
+			stats.addLeaf(p, om.Move, &child)
+		}
+	}
+}
+
+func (s *PerftStats) addLeaf(p *Position, move Move, child *Position) {
+	s.Nodes++
+	if move.CapturedPiece() != Empty {
+		s.Captures++
+		if move.To() == p.EpSquare && move.MovingPiece() == Pawn {
+			s.EnPassant++
+		}
+	}
+	if move.MovingPiece() == King && (move.To()-move.From() == 2 || move.From()-move.To() == 2) {
+		s.Castles++
+	}
+	if move.Promotion() != Empty {
+		s.Promotions++
+	}
+	if child.Checkers != 0 {
+		s.Checks++
+		var buffer [MaxMoves]OrderedMove
+		if len(child.GenerateLegal(buffer[:])) == 0 {
+			s.Checkmates++
+		}
+	}
+}
+
+// PerftHash caches subtree node counts, it is safe for concurrent use.
+// An entry keeps the data and the key xor data, so a torn write is never a hit.
+type PerftHash struct {
+	entries []perftEntry
+	mask    uint64
+}
+
+type perftEntry struct {
+	check uint64
+	data  uint64
+}
+
+func NewPerftHash(megabytes int) *PerftHash {
+	var size = 1
+	for (size << 1) <= 1024*1024*megabytes/16 {
+		size <<= 1
+	}
+	return &PerftHash{
+		entries: make([]perftEntry, size),
+		mask:    uint64(size - 1),
+	}
+}
+
+func (h *PerftHash) get(key uint64, depth int) (int, bool) {
+	var entry = &h.entries[key&h.mask]
+	var data = atomic.LoadUint64(&entry.data)
+	var check = atomic.LoadUint64(&entry.check)
+	if check^data != key || int(data&0xff) != depth {
+		return 0, false
+	}
+	return int(data >> 8), true
+}
+
+func (h *PerftHash) put(key uint64, depth, nodes int) {
+	var entry = &h.entries[key&h.mask]
+	var data = uint64(nodes)<<8 | uint64(depth)
+	atomic.StoreUint64(&entry.data, data)
+	atomic.StoreUint64(&entry.check, key^data)
+}
+
+func hashPerft(p *Position, depth int, h *PerftHash) int {
+	if depth <= 1 {
+		return LegalPerft(p, depth)
+	}
+	if nodes, ok := h.get(p.Key, depth); ok {
+		return nodes
+	}
+	var buffer [MaxMoves]OrderedMove
+	var child Position
+	var nodes = 0
+	for _, om := range p.GenerateLegal(buffer[:]) {
+		p.MakeMove(om.Move, &child)
+		nodes += hashPerft(&child, depth-1, h)
+	}
+	h.put(p.Key, depth, nodes)
+	return nodes
+}
+
+type PerftOptions struct {
+	Threads int
+	// Hash is used for node counts only, nil disables it
+	Hash  *PerftHash
+	Stats bool
+}
+
+type PerftDivideResult struct {
+	Move  Move
+	Stats PerftStats
+}
+
+// PerftDivide counts the nodes below every root move, root moves are searched in parallel.
+func PerftDivide(p *Position, depth int, options PerftOptions) []PerftDivideResult {
+	if depth <= 0 {
+		return nil
+	}
+	var buffer [MaxMoves]OrderedMove
+	var ml = p.GenerateLegal(buffer[:])
+	var result = make([]PerftDivideResult, len(ml))
+	var next int32 = -1
+	var threads = options.Threads
+	if threads < 1 {
+		threads = 1
+	}
+	var wg sync.WaitGroup
+	for t := 0; t < threads; t++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var child Position
+			for {
+				var i = int(atomic.AddInt32(&next, 1))
+				if i >= len(ml) {
+					return
+				}
+				var move = ml[i].Move
+				p.MakeMove(move, &child)
+				result[i].Move = move
+				if options.Stats {
+					if depth == 1 {
+						result[i].Stats.addLeaf(p, move, &child)
+					} else {
+						result[i].Stats = PerftWithStats(&child, depth-1)
+					}
+				} else if options.Hash != nil {
+					result[i].Stats.Nodes = hashPerft(&child, depth-1, options.Hash)
+				} else {
+					result[i].Stats.Nodes = LegalPerft(&child, depth-1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// FormatPerftDivide writes a line per root move and the total.
+func FormatPerftDivide(divide []PerftDivideResult, stats bool) string {
+	var sb = &strings.Builder{}
+	var total PerftStats
+	for _, item := range divide {
+		fmt.Fprintf(sb, "%v: %v\n", item.Move, item.Stats.Nodes)
+		total.Add(item.Stats)
+	}
+	fmt.Fprintf(sb, "\nNodes searched: %v\n", total.Nodes)
+	if stats {
+		fmt.Fprintln(sb, total.String())
+	}
+	return sb.String()
+}
@@ -4,70 +4,169 @@ import (
 	"testing"
 )
 
-//https://www.chessprogramming.org/Perft_Results
+// https://www.chessprogramming.org/Perft_Results
+var perftTests = []struct {
+	fen        string
+	depth      int
+	nodes      int
+	shortDepth int
+	shortNodes int
+}{
+	{
+		fen:        InitialPositionFen,
+		depth:      6,
+		nodes:      119060324,
+		shortDepth: 4,
+		shortNodes: 197281,
+	},
+	{
+		fen:        "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq -",
+		depth:      5,
+		nodes:      193690690,
+		shortDepth: 3,
+		shortNodes: 97862,
+	},
+	{
+		fen:        "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - -",
+		depth:      7,
+		nodes:      178633661,
+		shortDepth: 5,
+		shortNodes: 674624,
+	},
+	{
+		fen:        "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		depth:      5,
+		nodes:      15833292,
+		shortDepth: 4,
+		shortNodes: 422333,
+	},
+	{
+		fen:        "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+		depth:      5,
+		nodes:      89941194,
+		shortDepth: 3,
+		shortNodes: 62379,
+	},
+	{
+		fen:        "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+		depth:      5,
+		nodes:      164075551,
+		shortDepth: 3,
+		shortNodes: 89890,
+	},
+}
+
 func TestPerft(t *testing.T) {
+	for i, test := range perftTests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Error(i, test)
+		}
+		var depth, expected = test.depth, test.nodes
+		if testing.Short() {
+			depth, expected = test.shortDepth, test.shortNodes
+		}
+		var nodes = Perft(&p, depth)
+		if nodes != expected {
+			t.Error(i, test.fen, depth, nodes)
+		}
+	}
+}
+
+func TestLegalPerft(t *testing.T) {
+	for i, test := range perftTests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Error(i, test)
+		}
+		var depth, expected = test.depth, test.nodes
+		if testing.Short() {
+			depth, expected = test.shortDepth, test.shortNodes
+		}
+		var nodes = LegalPerft(&p, depth)
+		if nodes != expected {
+			t.Error(i, test.fen, depth, nodes)
+		}
+	}
+}
+
+func TestPerftStats(t *testing.T) {
 	var tests = []struct {
-		fen   string
-		depth int
-		nodes int
+		fen      string
+		depth    int
+		expected PerftStats
 	}{
-		{
-			fen:   InitialPositionFen,
-			depth: 6,
-			nodes: 119060324,
-		},
-		{
-			fen:   "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq -",
-			depth: 5,
-			nodes: 193690690,
-		},
-		{
-			fen:   "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - -",
-			depth: 7,
-			nodes: 178633661,
-		},
-		{
-			fen:   "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
-			depth: 5,
-			nodes: 15833292,
-		},
-		{
-			fen:   "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
-			depth: 5,
-			nodes: 89941194,
-		},
-		{
-			fen:   "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
-			depth: 5,
-			nodes: 164075551,
-		},
+		{InitialPositionFen, 4, PerftStats{Nodes: 197281, Captures: 1576, Checks: 469, Checkmates: 8}},
+		{perftTests[1].fen, 3, PerftStats{Nodes: 97862, Captures: 17102, EnPassant: 45, Castles: 3162, Checks: 993, Checkmates: 1}},
+		{perftTests[2].fen, 5, PerftStats{Nodes: 674624, Captures: 52051, EnPassant: 1165, Checks: 52950, Checkmates: 0}},
+		{perftTests[3].fen, 3, PerftStats{Nodes: 9467, Captures: 1021, EnPassant: 4, Promotions: 120, Checks: 38, Checkmates: 22}},
 	}
-	for i, test := range tests {
+	for _, test := range tests {
 		var p, err = NewPositionFromFEN(test.fen)
 		if err != nil {
-			t.Error(i, test)
+			t.Fatal(err)
 		}
-		var nodes = Perft(&p, test.depth)
-		if nodes != test.nodes {
-			t.Error(i, test, nodes)
+		var stats = PerftWithStats(&p, test.depth)
+		if stats != test.expected {
+			t.Errorf("%v depth %v: %v, expected %v", test.fen, test.depth, stats, test.expected)
 		}
 	}
 }
 
-func Perft(p *Position, depth int) int {
-	var result = 0
-	var buffer [MaxMoves]OrderedMove
-	var child Position
-	var ml = p.GenerateMoves(buffer[:])
-	for i := range ml {
-		var move = ml[i].Move
-		if p.MakeMove(move, &child) {
-			if depth > 1 {
-				result += Perft(&child, depth-1)
-			} else {
-				result++
+func TestPerftDivide(t *testing.T) {
+	for _, test := range perftTests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var depth = test.shortDepth
+		var expected = PerftDivide(&p, depth, PerftOptions{Threads: 1})
+		var hash = NewPerftHash(1)
+		for _, options := range []PerftOptions{
+			{Threads: 4},
+			{Threads: 4, Hash: hash},
+			{Threads: 4, Hash: hash},
+			{Threads: 2, Stats: true},
+		} {
+			var divide = PerftDivide(&p, depth, options)
+			var total = 0
+			for i := range divide {
+				if divide[i].Move != expected[i].Move || divide[i].Stats.Nodes != expected[i].Stats.Nodes {
+					t.Fatalf("%v %+v: %v %v, expected %v %v", test.fen, options,
+						divide[i].Move, divide[i].Stats.Nodes, expected[i].Move, expected[i].Stats.Nodes)
+				}
+				total += divide[i].Stats.Nodes
+			}
+			if total != test.shortNodes {
+				t.Errorf("%v %+v: total %v, expected %v", test.fen, options, total, test.shortNodes)
 			}
 		}
 	}
+	var p, _ = NewPositionFromFEN(InitialPositionFen)
+	var divide = PerftDivide(&p, 1, PerftOptions{Stats: true})
+	if len(divide) != 20 || divide[0].Stats.Nodes != 1 {
+		t.Error("divide depth 1", divide)
+	}
+}
+
+func perftPositions(t *testing.T) []Position {
+	var result []Position
+	for _, test := range perftTests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, p)
+	}
 	return result
 }
+
+func BenchmarkLegalPerft(b *testing.B) {
+	var p, err = NewPositionFromFEN(perftTests[1].fen)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		LegalPerft(&p, 3)
+	}
+}
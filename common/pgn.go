@@ -0,0 +1,190 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type PGNTag struct {
+	Name  string
+	Value string
+}
+
+const pgnLineLength = 80
+
+// FormatPGN exports a game given by its positions, positions[0] is the start position.
+// SetUp and FEN tags are added when the game does not start from the initial position,
+// ECO, Opening and Variation tags when the opening is known.
+func FormatPGN(tags []PGNTag, positions []Position, result string) string {
+	var sb = &strings.Builder{}
+	for _, tag := range tags {
+		fmt.Fprintf(sb, "[%v %q]\n", tag.Name, tag.Value)
+	}
+	var start = &positions[0]
+	var fen = start.String()
+	if fen != InitialPositionFen {
+		fmt.Fprintf(sb, "[%v %q]\n", "SetUp", "1")
+		fmt.Fprintf(sb, "[%v %q]\n", "FEN", fen)
+	}
+	if opening, ok := ClassifyOpening(positions); ok && !hasPGNTag(tags, "ECO") {
+		fmt.Fprintf(sb, "[%v %q]\n", "ECO", opening.ECO)
+		fmt.Fprintf(sb, "[%v %q]\n", "Opening", opening.Name)
+		if opening.Variation != "" {
+			fmt.Fprintf(sb, "[%v %q]\n", "Variation", opening.Variation)
+		}
+	}
+	sb.WriteString("\n")
+
+	// move numbers continue the fullmove number of the start position
+	var tokens []string
+	for i := 1; i < len(positions); i++ {
+		var parent = &positions[i-1]
+		if parent.WhiteMove {
+			tokens = append(tokens, strconv.Itoa(parent.FullMove)+".")
+		} else if i == 1 {
+			tokens = append(tokens, strconv.Itoa(parent.FullMove)+"...")
+		}
+		tokens = append(tokens, MoveToSAN(parent, positions[i].LastMove))
+	}
+	tokens = append(tokens, result)
+
+	var lineLength = 0
+	for i, token := range tokens {
+		if i > 0 {
+			if lineLength+1+len(token) > pgnLineLength {
+				sb.WriteString("\n")
+				lineLength = 0
+			} else {
+				sb.WriteString(" ")
+				lineLength++
+			}
+		}
+		sb.WriteString(token)
+		lineLength += len(token)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func hasPGNTag(tags []PGNTag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePGN parses a single game: tag pairs and movetext.
+// Comments, variations, NAGs and move numbers are skipped,
+// the FEN tag gives the start position.
+func ParsePGN(pgn string) (tags []PGNTag, positions []Position, result string, err error) {
+	var movetext = &strings.Builder{}
+	for _, line := range strings.Split(pgn, "\n") {
+		var trimmed = strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			var tag, tagErr = parsePGNTag(trimmed[1 : len(trimmed)-1])
+			if tagErr != nil {
+				return nil, nil, "", tagErr
+			}
+			tags = append(tags, tag)
+			continue
+		}
+		movetext.WriteString(line)
+		movetext.WriteString("\n")
+	}
+
+	var start Position
+	var fen = InitialPositionFen
+	for _, tag := range tags {
+		if tag.Name == "FEN" {
+			fen = tag.Value
+		}
+	}
+	start, err = NewPositionFromFEN(fen)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	positions, result, err = parseMovetext(start, movetext.String())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return tags, positions, result, nil
+}
+
+func parsePGNTag(s string) (PGNTag, error) {
+	var index = strings.IndexByte(s, ' ')
+	if index <= 0 {
+		return PGNTag{}, fmt.Errorf("invalid pgn tag %q", s)
+	}
+	var value, err = strconv.Unquote(strings.TrimSpace(s[index+1:]))
+	if err != nil {
+		return PGNTag{}, fmt.Errorf("invalid pgn tag %q", s)
+	}
+	return PGNTag{Name: s[:index], Value: value}, nil
+}
+
+func parseMovetext(start Position, movetext string) ([]Position, string, error) {
+	var positions = []Position{start}
+	var result = "*"
+	var variationDepth = 0
+	var i = 0
+	for i < len(movetext) {
+		var ch = movetext[i]
+		switch {
+		case ch == '{':
+			var end = strings.IndexByte(movetext[i:], '}')
+			if end < 0 {
+				return nil, "", fmt.Errorf("unterminated pgn comment")
+			}
+			i += end + 1
+			continue
+		case ch == ';':
+			var end = strings.IndexByte(movetext[i:], '\n')
+			if end < 0 {
+				end = len(movetext) - i
+			}
+			i += end
+			continue
+		case ch == '(':
+			variationDepth++
+			i++
+			continue
+		case ch == ')':
+			variationDepth--
+			i++
+			continue
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+			continue
+		}
+		var end = i
+		for end < len(movetext) && !strings.ContainsRune(" \t\r\n{};()", rune(movetext[end])) {
+			end++
+		}
+		var token = movetext[i:end]
+		i = end
+		if variationDepth > 0 || token[0] == '$' {
+			continue
+		}
+		switch token {
+		case "1-0", "0-1", "1/2-1/2", "*":
+			result = token
+			continue
+		}
+		// move numbers: 12. 12... or 12.e4
+		if digits := strings.TrimLeft(token, "0123456789"); strings.HasPrefix(digits, ".") {
+			token = strings.TrimLeft(digits, ".")
+			if token == "" {
+				continue
+			}
+		}
+		var child, err = playMoveText(&positions[len(positions)-1], token)
+		if err != nil {
+			return nil, "", fmt.Errorf("move %v: %v", positions[len(positions)-1].FullMove, err)
+		}
+		positions = append(positions, child)
+	}
+	return positions, result, nil
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatPGN(t *testing.T) {
+	var p, err = NewPositionFromFEN(InitialPositionFen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var positions = []Position{p}
+	for _, lan := range []string{"f2f3", "e7e5", "g2g4", "d8h4"} {
+		var child, ok = positions[len(positions)-1].MakeMoveLAN(lan)
+		if !ok {
+			t.Fatal(lan)
+		}
+		positions = append(positions, child)
+	}
+	var tags = []PGNTag{{Name: "White", Value: "A"}, {Name: "Black", Value: "B"}}
+	var expected = "[White \"A\"]\n[Black \"B\"]\n[ECO \"A00\"]\n[Opening \"Barnes Opening\"]\n[Variation \"Fool's Mate\"]\n\n" +
+		"1. f3 e5 2. g4 Qh4# 0-1\n"
+	if pgn := FormatPGN(tags, positions, "0-1"); pgn != expected {
+		t.Errorf("got %q, expected %q", pgn, expected)
+	}
+
+	var fen = "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"
+	p, err = NewPositionFromFEN(fen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, ok := p.MakeMoveLAN("e8d7")
+	if !ok {
+		t.Fatal("e8d7")
+	}
+	expected = "[SetUp \"1\"]\n[FEN \"" + fen + "\"]\n\n1... Kd7 *\n"
+	if pgn := FormatPGN(nil, []Position{p, child}, "*"); pgn != expected {
+		t.Errorf("got %q, expected %q", pgn, expected)
+	}
+}
+
+func TestFormatPGNMoveNumbers(t *testing.T) {
+	var tests = []struct {
+		fen      string
+		moves    []string
+		movetext string
+	}{
+		{"r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 20", []string{"f1b5", "a7a6"}, "20. Bb5 a6 *"},
+		{"r1bqkbnr/pppp1ppp/2n5/1B2p3/4P3/5N2/PPPP1PPP/RNBQK2R b KQkq - 3 20", []string{"a7a6", "b5a4"}, "20... a6 21. Ba4 *"},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var positions = []Position{p}
+		for _, lan := range test.moves {
+			var child, ok = positions[len(positions)-1].MakeMoveLAN(lan)
+			if !ok {
+				t.Fatal(lan)
+			}
+			positions = append(positions, child)
+		}
+		var pgn = FormatPGN(nil, positions, "*")
+		if !strings.HasSuffix(pgn, "\n\n"+test.movetext+"\n") {
+			t.Errorf("%v: got %q, expected %q", test.fen, pgn, test.movetext)
+		}
+	}
+}
+
+func TestParsePGN(t *testing.T) {
+	var pgn = `[Event "Test"]
+[White "A \"Quoted\""]
+
+1. e4 {best by test} e5 2.Nf3 $1 Nc6 (2... d6 3. d4) 3. Bb5 a6; Morphy
+4. Ba4 Nf6 5. 0-0 1/2-1/2
+`
+	var tags, positions, result, err = ParsePGN(pgn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[1].Value != "A \"Quoted\"" {
+		t.Error(tags)
+	}
+	if result != "1/2-1/2" || len(positions) != 10 {
+		t.Error(result, len(positions))
+	}
+	if fen := positions[9].String(); fen != "r1bqkb1r/1ppp1ppp/p1n2n2/4p3/B3P3/5N2/PPPP1PPP/RNBQ1RK1 b kq - 3 5" {
+		t.Error(fen)
+	}
+
+	tags, positions, result, err = ParsePGN("[SetUp \"1\"]\n[FEN \"4k3/8/8/8/8/8/4P3/4K3 b - - 0 1\"]\n\n1... Kd7 2. e4 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "*" || len(positions) != 3 || positions[0].WhiteMove {
+		t.Error(result, len(positions))
+	}
+
+	for _, invalid := range []string{
+		"1. e4 e5 2. Ke3",
+		"1. e4 {unterminated",
+		"[Event Test]\n1. e4",
+		"[FEN \"8/8/8\"]\n1. e4",
+	} {
+		if _, _, _, err := ParsePGN(invalid); err == nil {
+			t.Errorf("%q accepted", invalid)
+		}
+	}
+}
+
+func TestFormatParsePGN(t *testing.T) {
+	for _, positions := range [][]Position{fenCorpus(3, 80)[:80], fenCorpus(3, 80)[80:160]} {
+		var pgn = FormatPGN(nil, positions, "*")
+		var _, parsed, _, err = ParsePGN(pgn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(parsed) != len(positions) || parsed[len(parsed)-1] != positions[len(positions)-1] {
+			t.Errorf("%v: round trip failed", pgn)
+		}
+	}
+}
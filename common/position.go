@@ -2,10 +2,10 @@ package common
 
 import (
 	"fmt"
+	"math/bits"
 	"math/rand"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 type coloredPiece struct {
@@ -15,13 +15,15 @@ type coloredPiece struct {
 
 var castleMask [64]int
 
-func createPosition(board [64]coloredPiece, wtm bool,
-	castleRights, ep, fifty int) (Position, bool) {
+func createPosition(board [64]coloredPiece, variant Variant, wtm bool,
+	castleRights, ep, fifty, fullMove int) (Position, bool) {
 	var p = Position{
+		Variant:      variant,
 		WhiteMove:    wtm,
 		CastleRights: castleRights,
 		EpSquare:     ep,
 		Rule50:       fifty,
+		FullMove:     fullMove,
 		LastMove:     MoveEmpty,
 	}
 
@@ -34,63 +36,12 @@ func createPosition(board [64]coloredPiece, wtm bool,
 	p.Key = p.computeKey()
 	p.Checkers = p.computeCheckers()
 
-	if !p.isLegal() {
+	if !p.isKingSafe(!p.WhiteMove) {
 		return Position{}, false
 	}
 	return p, true
 }
 
-func NewPositionFromFEN(fen string) (Position, error) {
-	var tokens = strings.Split(fen, " ")
-	if len(tokens) <= 3 {
-		return Position{}, fmt.Errorf("parse fen failed %v", fen)
-	}
-
-	var board [64]coloredPiece
-
-	var i = 0
-	for _, ch := range tokens[0] {
-		if unicode.IsDigit(ch) {
-			var n, _ = strconv.Atoi(string(ch))
-			i += n
-		} else if unicode.IsLetter(ch) {
-			var pt = parsePiece(ch)
-			board[FlipSquare(i)] = pt
-			i++
-		}
-	}
-
-	var whiteMove = tokens[1] == "w"
-
-	var sCastleRights = tokens[2]
-	var cr = 0
-	if strings.Contains(sCastleRights, "K") {
-		cr |= WhiteKingSide
-	}
-	if strings.Contains(sCastleRights, "Q") {
-		cr |= WhiteQueenSide
-	}
-	if strings.Contains(sCastleRights, "k") {
-		cr |= BlackKingSide
-	}
-	if strings.Contains(sCastleRights, "q") {
-		cr |= BlackQueenSide
-	}
-
-	var epSquare = ParseSquare(tokens[3])
-
-	var rule50 = 0
-	if len(tokens) > 4 {
-		rule50, _ = strconv.Atoi(tokens[4])
-	}
-
-	var pos, isLegal = createPosition(board, whiteMove, cr, epSquare, rule50)
-	if !isLegal {
-		return Position{}, fmt.Errorf("parse fen failed %v", fen)
-	}
-	return pos, nil
-}
-
 func (p *Position) String() string {
 	var sb strings.Builder
 
@@ -109,6 +60,9 @@ func (p *Position) String() string {
 
 			var pieceSide = (p.White & SquareMask[sq]) != 0
 			sb.WriteString(pieceToChar(piece, pieceSide))
+			if (p.Promoted & SquareMask[sq]) != 0 {
+				sb.WriteString("~")
+			}
 		}
 
 		if File(sq) == FileH {
@@ -121,6 +75,9 @@ func (p *Position) String() string {
 			}
 		}
 	}
+	if p.Variant == VariantCrazyhouse {
+		sb.WriteString(p.pocketString())
+	}
 	sb.WriteString(" ")
 
 	if p.WhiteMove {
@@ -155,10 +112,14 @@ func (p *Position) String() string {
 	}
 	sb.WriteString(" ")
 
+	if p.Variant == VariantThreeCheck {
+		fmt.Fprintf(&sb, "%v+%v ", 3-p.Checks[0], 3-p.Checks[1])
+	}
+
 	sb.WriteString(strconv.Itoa(p.Rule50))
 	sb.WriteString(" ")
 
-	sb.WriteString(strconv.Itoa(p.Rule50/2 + 1))
+	sb.WriteString(strconv.Itoa(p.FullMove))
 
 	return sb.String()
 }
@@ -226,6 +187,10 @@ func (src *Position) MakeMove(move Move, result *Position) bool {
 	result.Kings = src.Kings
 	result.White = src.White
 	result.Black = src.Black
+	result.Variant = src.Variant
+	result.Checks = src.Checks
+	result.Pockets = src.Pockets
+	result.Promoted = src.Promoted
 
 	result.WhiteMove = !src.WhiteMove
 	result.Key = src.Key ^ sideKey
@@ -239,65 +204,89 @@ func (src *Position) MakeMove(move Move, result *Position) bool {
 		result.Rule50 = src.Rule50 + 1
 	}
 
+	result.FullMove = src.FullMove
+	if !src.WhiteMove {
+		result.FullMove++
+	}
+
 	result.EpSquare = SquareNone
 	if src.EpSquare != SquareNone {
 		result.Key ^= enpassantKey[File(src.EpSquare)]
 	}
 
+	result.togglePieces(move, src.WhiteMove, src.EpSquare)
+	if result.Variant == VariantCrazyhouse {
+		result.updatePockets(move, src.WhiteMove)
+	}
+	if result.Variant == VariantAtomic && capturedPiece != Empty {
+		result.explode(to)
+	}
+
+	if movingPiece == Pawn && (to == from+16 || to == from-16) {
+		result.EpSquare = (from + to) / 2
+		result.Key ^= enpassantKey[File(result.EpSquare)]
+	}
+
+	if !result.isKingSafe(src.WhiteMove) {
+		return false
+	}
+	result.Checkers = result.computeCheckers()
+	if result.Variant == VariantThreeCheck && result.Checkers != 0 {
+		var side = sideIndex(src.WhiteMove)
+		if result.Checks[side] < 3 {
+			result.Key ^= checksKey[side][result.Checks[side]] ^ checksKey[side][result.Checks[side]+1]
+			result.Checks[side]++
+		}
+	}
+	result.LastMove = move
+	return true
+}
+
+// togglePieces moves the pieces of move made by side.
+// Pieces are toggled with xor, so calling it again takes the move back.
+func (p *Position) togglePieces(move Move, side bool, epSquare int) {
+	var from = move.From()
+	var to = move.To()
+	var movingPiece = move.MovingPiece()
+	var capturedPiece = move.CapturedPiece()
+
+	if move.IsDrop() {
+		xorPiece(p, movingPiece, side, to)
+		return
+	}
+
 	if capturedPiece != Empty {
-		if capturedPiece == Pawn && to == src.EpSquare {
-			xorPiece(result, Pawn, !src.WhiteMove, to+let(src.WhiteMove, -8, 8))
+		if capturedPiece == Pawn && to == epSquare {
+			xorPiece(p, Pawn, !side, to+let(side, -8, 8))
 		} else {
-			xorPiece(result, capturedPiece, !src.WhiteMove, to)
+			xorPiece(p, capturedPiece, !side, to)
 		}
 	}
 
-	movePiece(result, movingPiece, src.WhiteMove, from, to)
+	movePiece(p, movingPiece, side, from, to)
 
 	if movingPiece == Pawn {
-		if src.WhiteMove {
-			if to == from+16 {
-				result.EpSquare = from + 8
-				result.Key ^= enpassantKey[File(from+8)]
-			}
-			if Rank(to) == Rank8 {
-				xorPiece(result, Pawn, true, to)
-				xorPiece(result, move.Promotion(), true, to)
-			}
-		} else {
-			if to == from-16 {
-				result.EpSquare = from - 8
-				result.Key ^= enpassantKey[File(from-8)]
-			}
-			if Rank(to) == Rank1 {
-				xorPiece(result, Pawn, false, to)
-				xorPiece(result, move.Promotion(), false, to)
-			}
+		if move.Promotion() != Empty {
+			xorPiece(p, Pawn, side, to)
+			xorPiece(p, move.Promotion(), side, to)
 		}
 	} else if movingPiece == King {
-		if src.WhiteMove {
+		if side {
 			if from == SquareE1 && to == SquareG1 {
-				movePiece(result, Rook, true, SquareH1, SquareF1)
+				movePiece(p, Rook, true, SquareH1, SquareF1)
 			}
 			if from == SquareE1 && to == SquareC1 {
-				movePiece(result, Rook, true, SquareA1, SquareD1)
+				movePiece(p, Rook, true, SquareA1, SquareD1)
 			}
 		} else {
 			if from == SquareE8 && to == SquareG8 {
-				movePiece(result, Rook, false, SquareH8, SquareF8)
+				movePiece(p, Rook, false, SquareH8, SquareF8)
 			}
 			if from == SquareE8 && to == SquareC8 {
-				movePiece(result, Rook, false, SquareA8, SquareD8)
+				movePiece(p, Rook, false, SquareA8, SquareD8)
 			}
 		}
 	}
-
-	if !result.isLegal() {
-		return false
-	}
-	result.Checkers = result.computeCheckers()
-	result.LastMove = move
-	return true
 }
 
 func (src *Position) MakeNullMove(result *Position) {
@@ -309,7 +298,15 @@ func (src *Position) MakeNullMove(result *Position) {
 	result.Kings = src.Kings
 	result.White = src.White
 	result.Black = src.Black
+	result.Variant = src.Variant
+	result.Checks = src.Checks
+	result.Pockets = src.Pockets
+	result.Promoted = src.Promoted
 	result.Rule50 = src.Rule50 + 1
+	result.FullMove = src.FullMove
+	if !src.WhiteMove {
+		result.FullMove++
+	}
 	result.CastleRights = src.CastleRights
 
 	result.WhiteMove = !src.WhiteMove
@@ -379,7 +376,8 @@ func movePiece(p *Position, piece int, side bool, from int, to int) {
 	p.Key ^= PieceSquareKey(piece, side, from) ^ PieceSquareKey(piece, side, to)
 }
 
-func (p *Position) isAttackedBySide(sq int, side bool) bool {
+// IsAttackedBySide reports whether a piece of side attacks sq
+func (p *Position) IsAttackedBySide(sq int, side bool) bool {
 	var enemy = p.PiecesByColor(side)
 	if (PawnAttacks(sq, !side) & p.Pawns & enemy) != 0 {
 		return true
@@ -400,26 +398,41 @@ func (p *Position) isAttackedBySide(sq int, side bool) bool {
 	return false
 }
 
-func (p *Position) attackersTo(sq int) uint64 {
-	var occ = p.White | p.Black
-	return (blackPawnAttacks[sq] & p.Pawns & p.White) |
+// AttackersTo returns the pieces of both sides on occ attacking sq,
+// x-ray attackers appear when a blocker is removed from occ
+func (p *Position) AttackersTo(sq int, occ uint64) uint64 {
+	return occ & ((blackPawnAttacks[sq] & p.Pawns & p.White) |
 		(whitePawnAttacks[sq] & p.Pawns & p.Black) |
 		(KnightAttacks[sq] & p.Knights) |
 		(BishopAttacks(sq, occ) & (p.Bishops | p.Queens)) |
 		(RookAttacks(sq, occ) & (p.Rooks | p.Queens)) |
-		(KingAttacks[sq] & p.Kings)
+		(KingAttacks[sq] & p.Kings))
 }
 
+// computeCheckers returns no checkers in antichess, which has no check
 func (p *Position) computeCheckers() uint64 {
+	switch p.Variant {
+	case VariantAntichess:
+		return 0
+	case VariantAtomic:
+		return p.atomicCheckers(p.WhiteMove)
+	}
 	if p.WhiteMove {
-		return p.attackersTo(FirstOne(p.Kings&p.White)) & p.Black
+		return p.Attackers(FirstOne(p.Kings&p.White), false)
 	}
-	return p.attackersTo(FirstOne(p.Kings&p.Black)) & p.White
+	return p.Attackers(FirstOne(p.Kings&p.Black), true)
 }
 
-func (p *Position) isLegal() bool {
-	var kingSq = FirstOne(p.Kings & p.PiecesByColor(!p.WhiteMove))
-	return !p.isAttackedBySide(kingSq, p.WhiteMove)
+// isKingSafe reports whether the king of side is not left in check
+func (p *Position) isKingSafe(side bool) bool {
+	switch p.Variant {
+	case VariantAntichess:
+		return true
+	case VariantAtomic:
+		return p.isAtomicKingSafe(side)
+	}
+	var kingSq = FirstOne(p.Kings & p.PiecesByColor(side))
+	return !p.IsAttackedBySide(kingSq, !side)
 }
 
 func (p *Position) IsCheck() bool {
@@ -441,7 +454,10 @@ func (p *Position) IsRepetition(other *Position) bool {
 		p.Kings == other.Kings &&
 		p.WhiteMove == other.WhiteMove &&
 		p.CastleRights == other.CastleRights &&
-		p.EpSquare == other.EpSquare
+		p.EpSquare == other.EpSquare &&
+		p.Checks == other.Checks &&
+		p.Pockets == other.Pockets &&
+		p.Promoted == other.Promoted
 }
 
 var (
@@ -449,6 +465,9 @@ var (
 	enpassantKey   [8]uint64
 	castlingKey    [16]uint64
 	pieceSquareKey [7 * 2 * 64]uint64
+	variantKey     [len(VariantNames)]uint64
+	checksKey      [2][4]uint64
+	pocketKey      [2][King][31]uint64
 )
 
 func PieceSquareKey(piece int, side bool, square int) uint64 {
@@ -471,6 +490,13 @@ func (p *Position) computeKey() uint64 {
 			result ^= PieceSquareKey(piece, side, i)
 		}
 	}
+	result ^= variantKey[p.Variant]
+	result ^= checksKey[0][p.Checks[0]] ^ checksKey[1][p.Checks[1]]
+	for side := range p.Pockets {
+		for piece, count := range p.Pockets[side] {
+			result ^= pocketKey[side][piece][count]
+		}
+	}
 	return result
 }
 
@@ -496,6 +522,23 @@ func initKeys() {
 			}
 		}
 	}
+
+	// standard chess, positions without checks and empty pockets keep their keys
+	for i := 1; i < len(variantKey); i++ {
+		variantKey[i] = r.Uint64()
+	}
+	for side := range checksKey {
+		for i := 1; i < len(checksKey[side]); i++ {
+			checksKey[side][i] = r.Uint64()
+		}
+	}
+	for side := range pocketKey {
+		for piece := Pawn; piece < King; piece++ {
+			for i := 1; i < len(pocketKey[side][piece]); i++ {
+				pocketKey[side][piece][i] = r.Uint64()
+			}
+		}
+	}
 }
 
 func MirrorPosition(p *Position) Position {
@@ -511,7 +554,11 @@ func MirrorPosition(p *Position) Position {
 	if p.EpSquare != SquareNone {
 		ep = FlipSquare(p.EpSquare)
 	}
-	var pos, _ = createPosition(board, !p.WhiteMove, cr, ep, p.Rule50)
+	var pos, _ = createPosition(board, p.Variant, !p.WhiteMove, cr, ep, p.Rule50, p.FullMove)
+	pos.Checks = [2]int{p.Checks[1], p.Checks[0]}
+	pos.Pockets = [2][King]uint8{p.Pockets[1], p.Pockets[0]}
+	pos.Promoted = bits.ReverseBytes64(p.Promoted)
+	pos.Key = pos.computeKey()
 	return pos
 }
 
@@ -0,0 +1,87 @@
+package common
+
+import "strings"
+
+// Attackers returns the pieces of side attacking sq
+func (p *Position) Attackers(sq int, side bool) uint64 {
+	return p.AttackersTo(sq, p.White|p.Black) & p.PiecesByColor(side)
+}
+
+// Pins returns the pieces of side pinned to their king and the enemy sliders pinning them
+func (p *Position) Pins(side bool) (pinned, pinners uint64) {
+	var own, opp = p.PiecesByColor(side), p.PiecesByColor(!side)
+	var kingSq = FirstOne(p.Kings & own)
+	var snipers = opp & ((RookAttacks(kingSq, 0) & (p.Rooks | p.Queens)) |
+		(BishopAttacks(kingSq, 0) & (p.Bishops | p.Queens)))
+	for ; snipers != 0; snipers &= snipers - 1 {
+		var sniper = FirstOne(snipers)
+		var blockers = betweenMask[kingSq][sniper] & (own | opp)
+		if blockers != 0 && !MoreThanOne(blockers) && (blockers&own) != 0 {
+			pinned |= blockers
+			pinners |= SquareMask[sniper]
+		}
+	}
+	return
+}
+
+// HangingPieces returns the pieces of side attacked by the enemy and not defended
+func (p *Position) HangingPieces(side bool) uint64 {
+	var result uint64
+	for b := p.PiecesByColor(side) &^ p.Kings; b != 0; b &= b - 1 {
+		var sq = FirstOne(b)
+		if p.Attackers(sq, !side) != 0 && p.Attackers(sq, side) == 0 {
+			result |= SquareMask[sq]
+		}
+	}
+	return result
+}
+
+// UnderdefendedPieces returns the pieces of side attacked by a cheaper piece
+// or by more pieces than defend them, hanging pieces included
+func (p *Position) UnderdefendedPieces(side bool) uint64 {
+	var occ = p.White | p.Black
+	var result uint64
+	for b := p.PiecesByColor(side) &^ p.Kings; b != 0; b &= b - 1 {
+		var sq = FirstOne(b)
+		var attackers = p.Attackers(sq, !side)
+		if attackers == 0 {
+			continue
+		}
+		var attacker, _ = p.LeastValuableAttacker(sq, !side, occ)
+		if SEEPieceValues[attacker] < SEEPieceValues[p.WhatPiece(sq)] ||
+			PopCount(attackers) > PopCount(p.Attackers(sq, side)) {
+			result |= SquareMask[sq]
+		}
+	}
+	return result
+}
+
+// MaterialSignature describes the material as in endgame tablebase names,
+// for example KRPvKR, white pieces first
+func (p *Position) MaterialSignature() string {
+	var sb = &strings.Builder{}
+	for i, side := range []uint64{p.White, p.Black} {
+		if i > 0 {
+			sb.WriteString("v")
+		}
+		for _, pc := range []struct {
+			name   string
+			pieces uint64
+		}{
+			{"K", p.Kings}, {"Q", p.Queens}, {"R", p.Rooks},
+			{"B", p.Bishops}, {"N", p.Knights}, {"P", p.Pawns},
+		} {
+			sb.WriteString(strings.Repeat(pc.name, PopCount(pc.pieces&side)))
+		}
+	}
+	return sb.String()
+}
+
+const MaxGamePhase = 24
+
+// GamePhase estimates the game phase from the pieces left on the board,
+// MaxGamePhase with all pieces and 0 with kings and pawns only
+func (p *Position) GamePhase() int {
+	var phase = PopCount(p.Knights|p.Bishops) + 2*PopCount(p.Rooks) + 4*PopCount(p.Queens)
+	return Min(phase, MaxGamePhase)
+}
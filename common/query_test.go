@@ -0,0 +1,122 @@
+package common
+
+import "testing"
+
+func TestSEE(t *testing.T) {
+	var tests = []struct {
+		fen   string
+		san   string
+		score int
+	}{
+		{"4k3/8/8/3n4/8/8/8/3RK3 w - - 0 1", "Rxd5", SEEPieceValues[Knight]},
+		{"4k3/8/4p3/3n4/8/8/8/3RK3 w - - 0 1", "Rxd5", SEEPieceValues[Knight] - SEEPieceValues[Rook]},
+		{"4k3/8/4p3/3n4/8/8/3Q4/3RK3 w - - 0 1", "Qxd5", SEEPieceValues[Knight] - SEEPieceValues[Queen] + SEEPieceValues[Pawn]},
+		{"4k3/8/4p3/3n4/8/8/3R4/3QK3 w - - 0 1", "Rxd5", SEEPieceValues[Knight] - SEEPieceValues[Rook] + SEEPieceValues[Pawn]},
+		{"k7/4P3/8/8/8/8/8/4K3 w - - 0 1", "e8=Q+", SEEPieceValues[Queen] - SEEPieceValues[Pawn]},
+		{"4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "Ra8+", 0},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var move, sanErr = ParseSAN(&p, test.san)
+		if sanErr != nil {
+			t.Fatal(sanErr)
+		}
+		var score = p.SEE(move)
+		if score != test.score {
+			t.Errorf("%v %v: %v, expected %v", test.fen, test.san, score, test.score)
+		}
+		if !p.SEEGE(move, score) || p.SEEGE(move, score+1) {
+			t.Errorf("%v %v: SEEGE disagrees with SEE %v", test.fen, test.san, score)
+		}
+	}
+}
+
+func TestAttackers(t *testing.T) {
+	var p, err = NewPositionFromFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Attackers(SquareF6, true); got != SquareMask[SquareF3] {
+		t.Errorf("white attackers of f6: %x", got)
+	}
+	if got := p.Attackers(SquareD5, false); got != SquareMask[SquareE6]|SquareMask[SquareB6]|SquareMask[SquareF6] {
+		t.Errorf("black attackers of d5: %x", got)
+	}
+	if !p.IsAttackedBySide(SquareA6, true) || p.IsAttackedBySide(SquareH8, true) {
+		t.Error("IsAttackedBySide")
+	}
+	// the bishop attacks through the removed knight
+	var occ = (p.White | p.Black) &^ SquareMask[SquareF6]
+	if got := p.AttackersTo(SquareE5, occ); got != SquareMask[SquareG7] {
+		t.Errorf("attackers of e5 without the knight on f6: %x", got)
+	}
+}
+
+func TestPins(t *testing.T) {
+	var p, err = NewPositionFromFEN("4r2k/8/8/8/1b6/8/3BQ3/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pinned, pinners = p.Pins(true)
+	if pinned != SquareMask[SquareE2]|SquareMask[SquareD2] || pinners != SquareMask[SquareE8]|SquareMask[SquareB4] {
+		t.Errorf("pinned %x pinners %x", pinned, pinners)
+	}
+	pinned, pinners = p.Pins(false)
+	if pinned != 0 || pinners != 0 {
+		t.Errorf("black pinned %x pinners %x", pinned, pinners)
+	}
+}
+
+func TestHangingPieces(t *testing.T) {
+	// the knight on g4 is hanging, the rook on a5 is attacked by a bishop and defended
+	var p, err = NewPositionFromFEN("4k3/8/1p6/r7/6n1/8/3B4/4K1R1 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.HangingPieces(false); got != SquareMask[SquareG4] {
+		t.Errorf("hanging: %x", got)
+	}
+	if got := p.UnderdefendedPieces(false); got != SquareMask[SquareG4]|SquareMask[SquareA5] {
+		t.Errorf("underdefended: %x", got)
+	}
+	// the knight is defended by a pawn
+	p, err = NewPositionFromFEN("4k3/8/1p6/r6p/6n1/8/3B4/4K1R1 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.UnderdefendedPieces(false); got != SquareMask[SquareA5] || p.HangingPieces(false) != 0 {
+		t.Errorf("underdefended: %x", got)
+	}
+	if got := p.HangingPieces(true); got != 0 {
+		t.Errorf("white hanging: %x", got)
+	}
+}
+
+func TestMaterialSignature(t *testing.T) {
+	var tests = []struct {
+		fen       string
+		signature string
+		phase     int
+	}{
+		{InitialPositionFen, "KQRRBBNNPPPPPPPPvKQRRBBNNPPPPPPPP", MaxGamePhase},
+		{"8/8/4k3/8/3RP3/2K5/8/4r3 w - - 0 1", "KRPvKR", 4},
+		{"8/8/4k3/8/8/2K5/8/8 w - - 0 1", "KvK", 0},
+		{"8/8/4k3/8/8/2K5/8/QQQQ4 w - - 0 1", "KQQQQvK", 16},
+		{"8/8/4k3/8/8/2K5/5QQQ/QQQQ4 w - - 0 1", "KQQQQQQQvK", MaxGamePhase},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signature := p.MaterialSignature(); signature != test.signature {
+			t.Errorf("%v: %v, expected %v", test.fen, signature, test.signature)
+		}
+		if phase := p.GamePhase(); phase != test.phase {
+			t.Errorf("%v: phase %v, expected %v", test.fen, phase, test.phase)
+		}
+	}
+}
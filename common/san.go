@@ -0,0 +1,237 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MoveToSAN returns the move in standard algebraic notation with + or # for check and mate.
+func MoveToSAN(pos *Position, move Move) string {
+	var san = moveToSAN(pos, pos.GenerateLegalMoves(), move)
+	var child Position
+	if pos.MakeMove(move, &child) && child.IsCheck() {
+		var buffer [MaxMoves]OrderedMove
+		if len(child.GenerateLegal(buffer[:])) == 0 {
+			return san + "#"
+		}
+		return san + "+"
+	}
+	return san
+}
+
+// sanMove is a parsed SAN string, the zero values of piece, fromFile and fromRank
+// and promotion mean that they were not given
+type sanMove struct {
+	piece     int
+	fromFile  int
+	fromRank  int
+	to        int
+	promotion int
+}
+
+// ParseSAN parses a move in SAN, including crazyhouse drops like P@e4, and accepts common deviations:
+// 0-0 castling, promotion without =, redundant disambiguation like Ng1f3,
+// e.p. suffix, lowercase piece letters and annotations like +, #, ! and ?.
+func ParseSAN(pos *Position, san string) (Move, error) {
+	var s = strings.TrimSpace(san)
+	s = strings.TrimRight(s, "+#!?")
+	s = strings.TrimSuffix(s, "e.p.")
+	s = strings.TrimSuffix(s, "ep")
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return MoveEmpty, fmt.Errorf("san %q: empty move", san)
+	}
+	var ml = pos.GenerateLegalMoves()
+
+	if i := strings.IndexByte(s, '@'); i >= 0 {
+		return findDrop(ml, san, s[:i], s[i+1:])
+	}
+
+	switch strings.ToUpper(strings.Replace(s, "0", "O", -1)) {
+	case "O-O", "OO":
+		return findCastle(ml, san, whiteKingSideCastle, blackKingSideCastle)
+	case "O-O-O", "OOO":
+		return findCastle(ml, san, whiteQueenSideCastle, blackQueenSideCastle)
+	}
+
+	var candidates, err = parseSANFields(s, pos.Variant)
+	if err != nil {
+		return MoveEmpty, fmt.Errorf("san %q: %v", san, err)
+	}
+	// the first reading matching a move wins
+	for _, sm := range candidates {
+		var matches = sm.match(ml)
+		if len(matches) > 1 {
+			return MoveEmpty, fmt.Errorf("san %q: ambiguous move, %v and %v match", san, matches[0], matches[1])
+		}
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+	}
+	// explain the most likely reason
+	for _, sm := range candidates {
+		if sm.promotion == Empty {
+			sm.promotion = Queen
+			if len(sm.match(ml)) != 0 {
+				return MoveEmpty, fmt.Errorf("san %q: promotion piece expected", san)
+			}
+		}
+	}
+	return MoveEmpty, fmt.Errorf("san %q: no legal move matches", san)
+}
+
+func findCastle(ml []Move, san string, white, black Move) (Move, error) {
+	for _, move := range ml {
+		if move == white || move == black {
+			return move, nil
+		}
+	}
+	return MoveEmpty, fmt.Errorf("san %q: castling is not legal", san)
+}
+
+// findDrop finds a crazyhouse drop like N@f3, a drop without piece letter is a pawn drop
+func findDrop(ml []Move, san, piece, square string) (Move, error) {
+	var dropped = Pawn
+	if len(piece) == 1 {
+		dropped = sanPiece(piece[0])
+		if piece[0] == 'b' {
+			dropped = Bishop
+		}
+	}
+	if len(piece) > 1 || dropped == Empty || dropped == King {
+		return MoveEmpty, fmt.Errorf("san %q: invalid piece to drop", san)
+	}
+	var to = ParseSquare(square)
+	if to == SquareNone {
+		return MoveEmpty, fmt.Errorf("san %q: invalid destination square %q", san, square)
+	}
+	for _, move := range ml {
+		if move.IsDrop() && move.MovingPiece() == dropped && move.To() == to {
+			return move, nil
+		}
+	}
+	return MoveEmpty, fmt.Errorf("san %q: drop is not legal", san)
+}
+
+// parseSANFields returns the possible readings of s,
+// a leading b is the file of a pawn or else a bishop.
+func parseSANFields(s string, variant Variant) ([]sanMove, error) {
+	var sm sanMove
+
+	// promotion: e8=Q, e8Q, e8(Q), e8/Q, e8=K in antichess
+	s = strings.TrimSuffix(s, ")")
+	if n := len(s); n >= 3 && isLetter(s[n-1]) {
+		var piece = sanPiece(s[n-1])
+		if s[n-1] == 'b' {
+			piece = Bishop
+		}
+		var maxPromotion = Queen
+		if variant == VariantAntichess {
+			maxPromotion = King
+		}
+		if piece < Knight || piece > maxPromotion {
+			return nil, fmt.Errorf("invalid promotion %q", s[n-1:])
+		}
+		sm.promotion = piece
+		s = strings.TrimRight(s[:n-1], "=(/")
+	}
+
+	if len(s) < 2 {
+		return nil, fmt.Errorf("destination square expected")
+	}
+	sm.to = ParseSquare(s[len(s)-2:])
+	if sm.to == SquareNone {
+		return nil, fmt.Errorf("invalid destination square %q", s[len(s)-2:])
+	}
+	s = s[:len(s)-2]
+	s = strings.TrimRight(s, "x:-")
+
+	var result []sanMove
+	if len(s) != 0 && s[0] == 'b' {
+		if pawn, err := parseSANFrom(sm, s); err == nil {
+			result = append(result, pawn)
+		}
+		sm.piece = Bishop
+		if bishop, err := parseSANFrom(sm, s[1:]); err == nil {
+			result = append(result, bishop)
+		}
+		if len(result) == 0 {
+			return nil, fmt.Errorf("invalid move syntax")
+		}
+		return result, nil
+	}
+	if len(s) != 0 {
+		if piece := sanPiece(s[0]); piece != Empty {
+			sm.piece = piece
+			s = s[1:]
+		}
+	}
+	var parsed, err = parseSANFrom(sm, s)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, parsed), nil
+}
+
+// parseSANFrom parses the disambiguation between the piece and the destination
+func parseSANFrom(sm sanMove, s string) (sanMove, error) {
+	s = strings.TrimRight(s, "x:-")
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] >= 'a' && s[i] <= 'h' && sm.fromFile == 0 && sm.fromRank == 0:
+			sm.fromFile = int(s[i]-'a') + 1
+		case isRankChar(s[i]) && sm.fromRank == 0:
+			sm.fromRank = int(s[i]-'1') + 1
+		default:
+			return sm, fmt.Errorf("invalid move syntax")
+		}
+	}
+	if sm.piece == Empty {
+		sm.piece = Pawn
+	}
+	if sm.piece != Pawn && sm.promotion != Empty {
+		return sm, fmt.Errorf("only a pawn can promote")
+	}
+	return sm, nil
+}
+
+func (sm *sanMove) match(ml []Move) []Move {
+	var result []Move
+	for _, move := range ml {
+		if move.MovingPiece() == sm.piece &&
+			move.To() == sm.to &&
+			move.Promotion() == sm.promotion &&
+			(sm.fromFile == 0 || File(move.From()) == sm.fromFile-1) &&
+			(sm.fromRank == 0 || Rank(move.From()) == sm.fromRank-1) {
+			result = append(result, move)
+		}
+	}
+	return result
+}
+
+func isLetter(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+func isRankChar(ch byte) bool {
+	return ch >= '1' && ch <= '8'
+}
+
+// sanPiece parses a piece letter in any case, except b which is left to the caller
+func sanPiece(ch byte) int {
+	switch ch {
+	case 'P', 'p':
+		return Pawn
+	case 'N', 'n':
+		return Knight
+	case 'B':
+		return Bishop
+	case 'R', 'r':
+		return Rook
+	case 'Q', 'q':
+		return Queen
+	case 'K', 'k':
+		return King
+	}
+	return Empty
+}
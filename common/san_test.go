@@ -0,0 +1,125 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSAN(t *testing.T) {
+	const (
+		kiwipete  = "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+		promotion = "k7/4P3/8/8/8/8/8/4K3 w - - 0 1"
+		enPassant = "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3"
+		knights   = "k7/8/8/8/8/8/8/KN3N2 w - - 0 1"
+		italian   = "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2"
+	)
+	var tests = []struct {
+		fen  string
+		san  string
+		move string
+	}{
+		{InitialPositionFen, "Nf3", "g1f3"},
+		{InitialPositionFen, "nf3", "g1f3"},
+		{InitialPositionFen, "Ng1f3", "g1f3"},
+		{InitialPositionFen, "Ng1-f3", "g1f3"},
+		{InitialPositionFen, "N1f3", "g1f3"},
+		{InitialPositionFen, " e4!? ", "e2e4"},
+		{InitialPositionFen, "Pe4", "e2e4"},
+		{InitialPositionFen, "e2e4", "e2e4"},
+		{kiwipete, "O-O", "e1g1"},
+		{kiwipete, "0-0", "e1g1"},
+		{kiwipete, "0-0-0", "e1c1"},
+		{kiwipete, "o-o-o", "e1c1"},
+		{kiwipete, "Qxf6", "f3f6"},
+		{kiwipete, "Bxa6", "e2a6"},
+		{kiwipete, "bxa6", "e2a6"},
+		{kiwipete, "dxe6", "d5e6"},
+		{promotion, "e8=Q+", "e7e8q"},
+		{promotion, "e8Q", "e7e8q"},
+		{promotion, "e8=q", "e7e8q"},
+		{promotion, "e8(N)", "e7e8n"},
+		{promotion, "e8/R", "e7e8r"},
+		{promotion, "e8b", "e7e8b"},
+		{enPassant, "exf6e.p.", "e5f6"},
+		{enPassant, "exf6 e.p.", "e5f6"},
+		{enPassant, "ef6", "e5f6"},
+		{knights, "Nbd2", "b1d2"},
+		{knights, "Nfd2", "f1d2"},
+		{italian, "bc4", "f1c4"},
+		{italian, "Bc4", "f1c4"},
+		{italian, "b3", "b2b3"},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var move, sanErr = ParseSAN(&p, test.san)
+		if sanErr != nil {
+			t.Errorf("%v %q: %v", test.fen, test.san, sanErr)
+		} else if move.String() != test.move {
+			t.Errorf("%v %q: %v, expected %v", test.fen, test.san, move, test.move)
+		}
+	}
+}
+
+func TestParseSANErrors(t *testing.T) {
+	var tests = []struct {
+		fen    string
+		san    string
+		reason string
+	}{
+		{InitialPositionFen, "", "empty move"},
+		{InitialPositionFen, "+", "empty move"},
+		{InitialPositionFen, "Nf6", "no legal move"},
+		{InitialPositionFen, "O-O", "castling is not legal"},
+		{InitialPositionFen, "Nz9", "invalid destination square"},
+		{InitialPositionFen, "Ng1g2f3", "invalid move syntax"},
+		{"k7/4P3/8/8/8/8/8/4K3 w - - 0 1", "e8", "promotion piece expected"},
+		{"k7/4P3/8/8/8/8/8/4K3 w - - 0 1", "e8K", "invalid promotion"},
+		{"k7/8/8/8/8/8/8/KN3N2 w - - 0 1", "Nd2", "ambiguous move"},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var move, sanErr = ParseSAN(&p, test.san)
+		if sanErr == nil {
+			t.Errorf("%v %q: parsed to %v", test.fen, test.san, move)
+		} else if !strings.Contains(sanErr.Error(), test.reason) {
+			t.Errorf("%v %q: error %q, expected %q", test.fen, test.san, sanErr, test.reason)
+		}
+		if ParseMoveSAN(&p, test.san) != MoveEmpty {
+			t.Errorf("%v %q: ParseMoveSAN accepted", test.fen, test.san)
+		}
+	}
+}
+
+func TestMoveToSAN(t *testing.T) {
+	var tests = []struct {
+		fen string
+		lan string
+		san string
+	}{
+		{InitialPositionFen, "g1f3", "Nf3"},
+		{"rnbqkbnr/pppp1ppp/8/4p3/6P1/5P2/PPPPP2P/RNBQKBNR b KQkq - 0 2", "d8h4", "Qh4#"},
+		{"4k3/8/8/8/8/8/8/R3K3 w Q - 0 1", "a1a8", "Ra8+"},
+		{"4k3/8/8/8/8/8/8/R3K3 w Q - 0 1", "e1c1", "O-O-O"},
+		{"k7/4P3/8/8/8/8/8/4K3 w - - 0 1", "e7e8q", "e8=Q+"},
+		{"k7/8/8/8/8/8/8/KN3N2 w - - 0 1", "b1d2", "Nbd2"},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var child, ok = p.MakeMoveLAN(test.lan)
+		if !ok {
+			t.Fatal(test.lan)
+		}
+		if san := MoveToSAN(&p, child.LastMove); san != test.san {
+			t.Errorf("%v %v: %v, expected %v", test.fen, test.lan, san, test.san)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package common
+
+// SEEPieceValues are the piece values of the static exchange evaluation
+var SEEPieceValues = [...]int{0, 1, 4, 4, 6, 12, 120}
+
+// LeastValuableAttacker returns the cheapest piece of side attacking to through occ
+func (p *Position) LeastValuableAttacker(to int, side bool, occ uint64) (attacker, from int) {
+	attacker = Empty
+	from = SquareNone
+	var att = p.AttackersTo(to, occ) & p.PiecesByColor(side)
+	if att == 0 {
+		return
+	}
+	var newTarget = SEEPieceValues[King] + 1
+	for ; att != 0; att &= att - 1 {
+		var f = FirstOne(att)
+		var piece = p.WhatPiece(f)
+		if SEEPieceValues[piece] < newTarget {
+			attacker = piece
+			from = f
+			newTarget = SEEPieceValues[piece]
+		}
+	}
+	return
+}
+
+// SEEGE reports whether the static exchange evaluation of move is at least bound
+func (p *Position) SEEGE(move Move, bound int) bool {
+	var piece = move.MovingPiece()
+	var score0 = SEEPieceValues[move.CapturedPiece()]
+	if promotion := move.Promotion(); promotion != Empty {
+		piece = move.Promotion()
+		score0 += SEEPieceValues[promotion] - SEEPieceValues[Pawn]
+	}
+	var to = move.To()
+	var occ = p.White ^ p.Black ^ SquareMask[move.From()]
+	occ |= SquareMask[to]
+	var side = !p.WhiteMove
+	var relativeStm = true
+	var balance = score0 - bound
+	if balance < 0 {
+		return false
+	}
+	balance -= SEEPieceValues[piece]
+	if balance >= 0 {
+		return true
+	}
+	for {
+		var nextVictim, from = p.LeastValuableAttacker(to, side, occ)
+		if nextVictim == Empty {
+			return relativeStm
+		}
+		if piece == King {
+			return !relativeStm
+		}
+		occ ^= SquareMask[from]
+		piece = nextVictim
+		if relativeStm {
+			balance += SEEPieceValues[nextVictim]
+		} else {
+			balance -= SEEPieceValues[nextVictim]
+		}
+		relativeStm = !relativeStm
+		if relativeStm == (balance >= 0) {
+			return relativeStm
+		}
+		side = !side
+	}
+}
+
+// SEE returns the material won by move when both sides capture on its destination
+// with the least valuable attacker and may stop at any time
+func (p *Position) SEE(move Move) int {
+	var from = move.From()
+	var to = move.To()
+	var pc = move.MovingPiece()
+	var sc = 0
+	if move.CapturedPiece() != Empty {
+		sc += SEEPieceValues[move.CapturedPiece()]
+	}
+	if move.Promotion() != Empty {
+		pc = move.Promotion()
+		sc += SEEPieceValues[pc] - SEEPieceValues[Pawn]
+	}
+	var pieces = (p.White | p.Black) &^ SquareMask[from]
+	sc -= p.seeRec(!p.WhiteMove, to, pieces, pc)
+	return sc
+}
+
+func (p *Position) seeRec(sd bool, to int, pieces uint64, cp int) int {
+	var bs = 0
+	var pc, from = p.LeastValuableAttacker(to, sd, pieces)
+	if from != SquareNone {
+		var sc = SEEPieceValues[cp]
+		if cp != King {
+			sc -= p.seeRec(!sd, to, pieces&^SquareMask[from], pc)
+		}
+		if sc > bs {
+			bs = sc
+		}
+	}
+	return bs
+}
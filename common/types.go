@@ -10,9 +10,17 @@ const (
 type Position struct {
 	Pawns, Knights, Bishops, Rooks, Queens, Kings, White, Black, Checkers uint64
 	WhiteMove                                                             bool
-	CastleRights, Rule50, EpSquare                                        int
+	CastleRights, Rule50, EpSquare, FullMove                              int
 	Key                                                                   uint64
 	LastMove                                                              Move
+	// Pockets are the pieces in hand of white and black in crazyhouse by piece type,
+	// bytes keep the position small for copy-make
+	Pockets [2][King]uint8
+	Variant Variant
+	// Checks given by white and black in three-check
+	Checks [2]int
+	// Promoted pieces return to the pocket as pawns when captured
+	Promoted uint64
 }
 
 const InitialPositionFen = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
@@ -28,7 +36,10 @@ const (
 )
 
 const (
-	MaxMoves = 256
+	// MaxMoves bounds the moves of a position in any variant, crazyhouse drops included
+	MaxMoves = 512
+	// MaxBoardMoves bounds the moves of the variants without drops
+	MaxBoardMoves = 256
 )
 
 const (
@@ -152,11 +163,15 @@ type SearchParams struct {
 }
 
 type SearchInfo struct {
-	Score    UciScore
-	Depth    int
-	Nodes    int64
-	Time     int64
-	MainLine []Move
+	Score          UciScore
+	Depth          int
+	SelDepth       int
+	Nodes          int64
+	Time           int64
+	HashFull       int
+	MainLine       []Move
+	CurrMove       Move
+	CurrMoveNumber int
 }
 
 type UciScore struct {
@@ -2,7 +2,6 @@ package common
 
 import (
 	"strings"
-	"unicode"
 )
 
 func Min(l, r int) int {
@@ -80,19 +79,23 @@ func ParseSquare(s string) int {
 	if s == "-" {
 		return SquareNone
 	}
-	var file = strings.Index(fileNames, s[0:1])
-	var rank = strings.Index(rankNames, s[1:2])
+	if len(s) != 2 {
+		return SquareNone
+	}
+	var file = strings.IndexByte(fileNames, s[0])
+	var rank = strings.IndexByte(rankNames, s[1])
+	if file < 0 || rank < 0 {
+		return SquareNone
+	}
 	return MakeSquare(file, rank)
 }
 
 func parsePiece(ch rune) coloredPiece {
-	var side = unicode.IsUpper(ch)
-	var spiece = string(unicode.ToLower(ch))
-	var i = strings.Index("pnbrqk", spiece)
+	var i = strings.IndexRune("pnbrqkPNBRQK", ch)
 	if i < 0 {
 		return coloredPiece{Empty, false}
 	}
-	return coloredPiece{i + Pawn, side}
+	return coloredPiece{i%6 + Pawn, i >= 6}
 }
 
 func makeMove(from, to, movingPiece, capturedPiece int) Move {
@@ -103,6 +106,16 @@ func makePawnMove(from, to, capturedPiece, promotion int) Move {
 	return Move(from ^ (to << 6) ^ (Pawn << 12) ^ (capturedPiece << 15) ^ (promotion << 18))
 }
 
+// makeDrop returns a crazyhouse drop, the from and to squares of a drop are the same
+func makeDrop(piece, to int) Move {
+	return makeMove(to, to, piece, Empty)
+}
+
+// IsDrop reports whether the move puts a piece from the pocket on the board
+func (m Move) IsDrop() bool {
+	return m != MoveEmpty && m.From() == m.To()
+}
+
 func (m Move) From() int {
 	return int(m & 63)
 }
@@ -142,9 +155,12 @@ func (m Move) String() string {
 	if m == MoveEmpty {
 		return "0000"
 	}
+	if m.IsDrop() {
+		return string("PNBRQ"[m.MovingPiece()-Pawn]) + "@" + SquareName(m.To())
+	}
 	var sPromotion = ""
 	if m.Promotion() != Empty {
-		sPromotion = string("nbrq"[m.Promotion()-Knight])
+		sPromotion = string("nbrqk"[m.Promotion()-Knight])
 	}
 	return SquareName(m.From()) + SquareName(m.To()) + sPromotion
 }
@@ -168,6 +184,9 @@ func (p *Position) MakeMoveLAN(lan string) (Position, bool) {
 
 func moveToSAN(pos *Position, ml []Move, mv Move) string {
 	const PieceNames = "NBRQK"
+	if mv.IsDrop() {
+		return mv.String()
+	}
 	if mv == whiteKingSideCastle || mv == blackKingSideCastle {
 		return "O-O"
 	}
@@ -221,16 +240,11 @@ func moveToSAN(pos *Position, ml []Move, mv Move) string {
 	return strPiece + strFrom + strCapture + strTo + strPromotion
 }
 
+// ParseMoveSAN is ParseSAN returning MoveEmpty for an invalid move
 func ParseMoveSAN(pos *Position, san string) Move {
-	var index = strings.IndexAny(san, "+#?!")
-	if index >= 0 {
-		san = san[:index]
-	}
-	var ml = pos.GenerateLegalMoves()
-	for _, mv := range ml {
-		if san == moveToSAN(pos, ml, mv) {
-			return mv
-		}
+	var move, err = ParseSAN(pos, san)
+	if err != nil {
+		return MoveEmpty
 	}
-	return MoveEmpty
+	return move
 }
@@ -0,0 +1,167 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Variant is the set of rules a position is played by
+type Variant int
+
+const (
+	VariantStandard Variant = iota
+	VariantThreeCheck
+	VariantKingOfTheHill
+	VariantAntichess
+	VariantCrazyhouse
+	VariantAtomic
+)
+
+// VariantNames are the UCI_Variant names of the variants
+var VariantNames = [...]string{"chess", "3check", "kingofthehill", "antichess", "crazyhouse", "atomic"}
+
+func (v Variant) String() string {
+	return VariantNames[v]
+}
+
+// ParseVariant accepts UCI_Variant names and the lichess keys of the variants
+func ParseVariant(s string) (Variant, error) {
+	switch strings.ToLower(s) {
+	case "chess", "standard", "normal":
+		return VariantStandard, nil
+	case "3check", "threecheck":
+		return VariantThreeCheck, nil
+	case "kingofthehill", "koth":
+		return VariantKingOfTheHill, nil
+	case "antichess":
+		return VariantAntichess, nil
+	case "crazyhouse", "zh":
+		return VariantCrazyhouse, nil
+	case "atomic":
+		return VariantAtomic, nil
+	}
+	return VariantStandard, fmt.Errorf("unknown variant %q", s)
+}
+
+// MaxMoves is the size of a move buffer for the positions of the variant
+func (v Variant) MaxMoves() int {
+	if v == VariantCrazyhouse {
+		return MaxMoves
+	}
+	return MaxBoardMoves
+}
+
+// StartFEN returns the initial position of the variant
+func (v Variant) StartFEN() string {
+	switch v {
+	case VariantThreeCheck:
+		return "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 3+3 0 1"
+	case VariantAntichess:
+		return "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1"
+	case VariantCrazyhouse:
+		return "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[] w KQkq - 0 1"
+	}
+	return InitialPositionFen
+}
+
+const hillSquares = uint64(1)<<SquareD4 | uint64(1)<<SquareE4 | uint64(1)<<SquareD5 | uint64(1)<<SquareE5
+
+// VariantEnd reports whether a rule of the variant ended the game before the side to move plays.
+// Result is 1 when the side to move has won, -1 when it has lost and 0 for a draw.
+// An antichess player without moves also wins, see NoMovesResult.
+func (p *Position) VariantEnd() (ended bool, result int) {
+	switch p.Variant {
+	case VariantThreeCheck:
+		if p.Checks[sideIndex(!p.WhiteMove)] >= 3 {
+			return true, -1
+		}
+	case VariantKingOfTheHill:
+		if (p.Kings & p.PiecesByColor(!p.WhiteMove) & hillSquares) != 0 {
+			return true, -1
+		}
+	case VariantAntichess:
+		if p.PiecesByColor(p.WhiteMove) == 0 {
+			return true, 1
+		}
+	case VariantAtomic:
+		if (p.Kings & p.PiecesByColor(p.WhiteMove)) == 0 {
+			return true, -1
+		}
+	}
+	return false, 0
+}
+
+// NoMovesResult is the result for the side to move when it has no legal moves,
+// as VariantEnd: mated, stalemated or, in antichess, the winner.
+func (p *Position) NoMovesResult() int {
+	if p.Variant == VariantAntichess {
+		return 1
+	}
+	if p.IsCheck() {
+		return -1
+	}
+	return 0
+}
+
+func (p *Position) isVariantEnd() bool {
+	if p.Variant == VariantStandard {
+		return false
+	}
+	var ended, _ = p.VariantEnd()
+	return ended
+}
+
+// sideIndex is the index of side in per side arrays, white first
+func sideIndex(side bool) int {
+	if side {
+		return 0
+	}
+	return 1
+}
+
+// NewPositionFromVariantFEN parses a FEN of the variant.
+// Three-check positions may have the remaining checks of both sides after the en passant field, as in 3+3.
+// Crazyhouse positions have the pockets after the placement, as in [Qp], and promoted pieces are marked with ~.
+func NewPositionFromVariantFEN(variant Variant, fen string) (Position, error) {
+	var tokens = strings.Fields(fen)
+	var checks [2]int
+	var pockets [2][King]uint8
+	var promoted uint64
+	if variant == VariantCrazyhouse && len(tokens) != 0 {
+		var err error
+		tokens[0], pockets, promoted, err = parseCrazyhousePlacement(tokens[0])
+		if err != nil {
+			return Position{}, err
+		}
+	}
+	if variant == VariantThreeCheck && len(tokens) > 4 && strings.Contains(tokens[4], "+") {
+		var remaining [2]int
+		if n, err := fmt.Sscanf(tokens[4], "%d+%d", &remaining[0], &remaining[1]); err != nil || n != 2 ||
+			remaining[0] < 0 || remaining[0] > 3 || remaining[1] < 0 || remaining[1] > 3 {
+			return Position{}, fenError(FENCheckCounter, tokens[4], "expected remaining checks as 3+3")
+		}
+		checks = [2]int{3 - remaining[0], 3 - remaining[1]}
+		tokens = append(tokens[:4], tokens[5:]...)
+	}
+	var p, err = parseFEN(strings.Join(tokens, " "), variant)
+	if err != nil {
+		return Position{}, err
+	}
+	p.Checks = checks
+	p.Pockets = pockets
+	p.Promoted = promoted
+	if (promoted &^ (p.Knights | p.Bishops | p.Rooks | p.Queens)) != 0 {
+		return Position{}, fenError(FENPlacement, tokens[0], "only pieces a pawn promotes to can be promoted")
+	}
+	var pieces = PopCount(p.White | p.Black)
+	for _, pocket := range pockets {
+		for _, count := range pocket {
+			pieces += int(count)
+		}
+	}
+	if pieces > 32 {
+		return Position{}, fenError(FENPocket, fen, "%v pieces on the board and in the pockets", pieces)
+	}
+	p.Key = p.computeKey()
+	return p, nil
+}
@@ -0,0 +1,203 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVariant(t *testing.T) {
+	var tests = []struct {
+		name    string
+		variant Variant
+	}{
+		{"chess", VariantStandard},
+		{"standard", VariantStandard},
+		{"3check", VariantThreeCheck},
+		{"threeCheck", VariantThreeCheck},
+		{"kingOfTheHill", VariantKingOfTheHill},
+		{"antichess", VariantAntichess},
+		{"zh", VariantCrazyhouse},
+		{"atomic", VariantAtomic},
+	}
+	for _, test := range tests {
+		var variant, err = ParseVariant(test.name)
+		if err != nil || variant != test.variant {
+			t.Errorf("%v: %v %v", test.name, variant, err)
+		}
+	}
+	if _, err := ParseVariant("bughouse"); err == nil {
+		t.Error("unknown variant accepted")
+	}
+}
+
+func TestVariantFEN(t *testing.T) {
+	var p, err = NewPositionFromVariantFEN(VariantThreeCheck, VariantThreeCheck.StartFEN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != VariantThreeCheck.StartFEN() {
+		t.Error(p.String())
+	}
+	var standard, _ = NewPositionFromFEN(InitialPositionFen)
+	if p.Key == standard.Key {
+		t.Error("variant is not hashed")
+	}
+
+	p, err = NewPositionFromVariantFEN(VariantThreeCheck, InitialPositionFen)
+	if err != nil || p.String() != VariantThreeCheck.StartFEN() {
+		t.Errorf("default check counter: %v %v", p.String(), err)
+	}
+
+	const fen = "rnbqkbnr/ppp2ppp/8/3pp3/4P3/5Q2/PPPP1PPP/RNB1KBNR w KQkq - 2+3 0 3"
+	p, err = NewPositionFromVariantFEN(VariantThreeCheck, fen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != fen || p.Checks != [2]int{1, 0} {
+		t.Errorf("%v %v", p.String(), p.Checks)
+	}
+	var child, ok = p.MakeMoveLAN("f1b5")
+	if !ok || child.Checks != [2]int{2, 0} || child.Key != child.computeKey() {
+		t.Errorf("check not counted: %v", child.String())
+	}
+	if mirror := MirrorPosition(&child); mirror.Checks != [2]int{0, 2} || mirror.Variant != VariantThreeCheck {
+		t.Errorf("mirror: %v", mirror.String())
+	}
+
+	_, err = NewPositionFromVariantFEN(VariantThreeCheck, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 4+3 0 1")
+	var fenErr *FENError
+	if !errors.As(err, &fenErr) || fenErr.Field != FENCheckCounter {
+		t.Errorf("invalid check counter: %v", err)
+	}
+}
+
+func TestVariantPerft(t *testing.T) {
+	var tests = []struct {
+		variant Variant
+		fen     string
+		nodes   []int
+	}{
+		// the third check ends the game
+		{VariantThreeCheck, "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 1+1 0 1", []int{48, 2039, 97848}},
+		{VariantThreeCheck, VariantThreeCheck.StartFEN(), []int{20, 400, 8902, 197281}},
+		// the king reaches the hill on d4 or e4
+		{VariantKingOfTheHill, "8/8/8/8/8/4K3/8/k7 w - - 0 1", []int{8, 18}},
+		{VariantKingOfTheHill, InitialPositionFen, []int{20, 400, 8902, 197281}},
+		{VariantAntichess, VariantAntichess.StartFEN(), []int{20, 400, 8067, 153299, 2732672}},
+		// captures are compulsory
+		{VariantAntichess, "8/1P6/8/8/8/8/3pp3/3R4 w - - 0 1", []int{1, 5}},
+		// a pawn promotes to a king too
+		{VariantAntichess, "8/1P6/8/8/8/8/8/k7 w - - 0 1", []int{5, 15}},
+		{VariantCrazyhouse, VariantCrazyhouse.StartFEN(), []int{20, 400, 8902, 197281, 4888832}},
+		{VariantCrazyhouse, "4k3/8/8/8/8/8/8/4K3[Q] w - - 0 1", []int{67}},
+		// drops block the check, pawns are not dropped on the first rank
+		{VariantCrazyhouse, "4k3/8/8/8/8/8/8/r3K3[N] w - - 0 1", []int{6}},
+		{VariantCrazyhouse, "4k3/8/8/8/8/8/8/r3K3[P] w - - 0 1", []int{3}},
+		{VariantAtomic, InitialPositionFen, []int{20, 400, 8902, 197326}},
+		{VariantAtomic, "rn2kb1r/1pp1p2p/p2q1pp1/3P4/2P3b1/4PN2/PP3PPP/R2QKB1R b KQkq - 0 1", []int{40, 1238, 45237, 1434825}},
+		{VariantAtomic, "rn1qkb1r/p5pp/2p5/3p4/N3P3/5P2/PPP4P/R1BQK3 w Qkq - 0 1", []int{28, 833, 23353, 714499}},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromVariantFEN(test.variant, test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, nodes := range test.nodes {
+			var depth = i + 1
+			if depth >= 4 && testing.Short() {
+				break
+			}
+			if got := Perft(&p, depth); got != nodes {
+				t.Errorf("%v %v depth %v: %v, expected %v", test.variant, test.fen, depth, got, nodes)
+			}
+			if got := LegalPerft(&p, depth); got != nodes {
+				t.Errorf("%v %v depth %v: legal %v, expected %v", test.variant, test.fen, depth, got, nodes)
+			}
+			if got := perftDoMove(&p, depth); got != nodes {
+				t.Errorf("%v %v depth %v: doMove %v, expected %v", test.variant, test.fen, depth, got, nodes)
+			}
+		}
+	}
+}
+
+func TestVariantOutcome(t *testing.T) {
+	var tests = []struct {
+		variant     Variant
+		fen         string
+		moves       string
+		termination Termination
+		result      string
+	}{
+		{VariantThreeCheck, "4k3/8/8/8/8/8/8/R3K3 w - - 1+3 0 1", "Ra8", ThirdCheck, "1-0"},
+		{VariantThreeCheck, "4k3/8/8/8/8/8/8/R3K3 w - - 1+3 0 1", "Ra7", TerminationNone, "*"},
+		{VariantKingOfTheHill, "8/8/8/3k4/8/4K3/8/8 b - - 0 1", "Ke5", KingOnTheHill, "0-1"},
+		{VariantKingOfTheHill, "8/8/8/8/8/4K3/8/k7 w - - 0 1", "Ke2", TerminationNone, "*"},
+		{VariantThreeCheck, "4k3/8/8/8/8/8/8/4K3 w - - 0 1", "", InsufficientMaterial, "1/2-1/2"},
+		{VariantThreeCheck, "4k3/8/8/8/8/8/8/4KN2 w - - 0 1", "", TerminationNone, "*"},
+		{VariantAntichess, "8/8/8/8/8/8/1p6/2R5 b - - 0 1", "bxc1=K", AllPiecesLost, "1-0"},
+		{VariantAntichess, "8/8/8/8/8/p7/P7/8 w - - 0 1", "", Stalemate, "1-0"},
+		{VariantAntichess, "8/8/8/8/8/8/kp6/K7 w - - 0 1", "Kxa2", TerminationNone, "*"},
+		{VariantAntichess, "8/8/8/8/8/8/8/K7 w - - 0 1", "", TerminationNone, "*"},
+		{VariantCrazyhouse, "k7/8/1K6/8/8/8/8/8[Q] w - - 0 1", "Q@a7", Checkmate, "1-0"},
+		{VariantCrazyhouse, "4k3/8/8/8/8/8/8/4K3[] w - - 0 1", "", InsufficientMaterial, "1/2-1/2"},
+		{VariantCrazyhouse, "4k3/8/8/8/8/8/8/4K3[p] w - - 0 1", "", TerminationNone, "*"},
+		// the explosion wins although the own king is in check
+		{VariantAtomic, "3nk3/8/8/Q7/8/8/8/4K2r w - - 0 1", "Qxd8", KingExploded, "1-0"},
+		// kings next to each other give no check
+		{VariantAtomic, "8/8/8/8/8/4k3/r3K3/8 w - - 0 1", "", TerminationNone, "*"},
+		{VariantAtomic, "4k3/8/8/8/8/8/8/4KB2 w - - 0 1", "", InsufficientMaterial, "1/2-1/2"},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromVariantFEN(test.variant, test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var positions, movesErr = ParseMoves(p, test.moves)
+		if movesErr != nil {
+			t.Fatal(movesErr)
+		}
+		var outcome = GameOutcome(positions, false)
+		if outcome.Termination != test.termination || outcome.Result != test.result {
+			t.Errorf("%v %v %v: %v %v", test.variant, test.fen, test.moves, outcome, outcome.Result)
+		}
+		var decisive = test.result == "1-0" || test.result == "0-1"
+		if decisive && len(positions[len(positions)-1].GenerateLegalMoves()) != 0 {
+			t.Errorf("%v %v %v: moves after the end", test.variant, test.fen, test.moves)
+		}
+	}
+}
+
+func TestCrazyhouse(t *testing.T) {
+	var p, err = NewPositionFromVariantFEN(VariantCrazyhouse, "4k3/3Q~4/8/8/8/8/8/4K3[Nn] b - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var child, ok = p.MakeMoveLAN("e8d7")
+	// the promoted queen returns to the pocket as a pawn
+	if !ok || child.String() != "8/3k4/8/8/8/8/8/4K3[Nnp] w - - 0 2" || child.Key != child.computeKey() {
+		t.Errorf("capture of a promoted piece: %v", child.String())
+	}
+
+	var drop = ParseMoveSAN(&child, "N@f6")
+	if drop != makeDrop(Knight, SquareF6) || drop.String() != "N@f6" || MoveToSAN(&child, drop) != "N@f6+" {
+		t.Errorf("drop %v", drop)
+	}
+	var dropped Position
+	if !child.MakeMove(drop, &dropped) || dropped.String() != "8/3k4/5N2/8/8/8/8/4K3[np] b - - 1 2" ||
+		dropped.Key != dropped.computeKey() || !dropped.IsCheck() {
+		t.Errorf("drop: %v", dropped.String())
+	}
+	if mirror := MirrorPosition(&p); mirror.String() != "4k3/8/8/8/8/8/3q~4/4K3[Nn] w - - 0 1" {
+		t.Errorf("mirror: %v", mirror.String())
+	}
+
+	for _, fen := range []string{
+		"4k3/8/8/8/8/8/8/4K3[K] w - - 0 1",
+		"4k3/8/8/8/8/8/8/4K3[Q w - - 0 1",
+		"4k3/8/8/8/8/8/3P~4/4K3[] w - - 0 1",
+	} {
+		if _, err := NewPositionFromVariantFEN(VariantCrazyhouse, fen); err == nil {
+			t.Errorf("%v: invalid fen accepted", fen)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/uci"
+)
+
+// consoleCommands start the console mode when sent as the first command
+var consoleCommands = map[string]bool{
+	"help": true, "move": true, "board": true, "new": true, "undo": true,
+	"flip": true, "hint": true, "fen": true, "pgn": true, "level": true, "time": true,
+	"perft": true,
+}
+
+const consoleHelp = `commands:
+  move <move>           play a move in SAN (Nf3) or LAN (g1f3), the engine answers
+  <move>                same as move
+  go                    the engine plays the side to move
+  undo                  take back the last move pair
+  new                   start a new game
+  fen [<fen>]           show the position as FEN or set up a position
+  flip                  flip the board
+  board                 show the board
+  hint                  suggest a move
+  time <seconds>        engine time per move
+  level <minutes> <inc> play with clocks, increment in seconds
+  pgn [<file>]          show the game in PGN or save it to a file
+  perft <depth> [stats] count the nodes below every move
+  quit                  exit`
+
+// console is a human friendly mode to play against the engine
+type console struct {
+	engine      uci.Engine
+	out         io.Writer
+	positions   []common.Position
+	flipped     bool
+	moveTime    int
+	baseTime    int
+	increment   int
+	clocks      [2]int
+	engineMoves []bool
+	result      string
+	turnStart   time.Time
+}
+
+func newConsole(engine uci.Engine) *console {
+	return &console{
+		engine:   engine,
+		moveTime: 3000,
+	}
+}
+
+func (c *console) Run(r io.Reader, w io.Writer) error {
+	c.out = w
+	if err := c.newGame(); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, "type help for the list of commands")
+	var scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var commandLine = scanner.Text()
+		if commandLine == "quit" {
+			return nil
+		}
+		var err = c.handleCommand(commandLine)
+		if err != nil {
+			fmt.Fprintln(c.out, "error:", err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (c *console) handleCommand(commandLine string) error {
+	var fields = strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return nil
+	}
+	var commandName = fields[0]
+	fields = fields[1:]
+
+	switch commandName {
+	case "help":
+		fmt.Fprintln(c.out, consoleHelp)
+		return nil
+	case "move":
+		if len(fields) == 0 {
+			return errors.New("move expected")
+		}
+		return c.humanMove(fields[0])
+	case "go":
+		return c.engineMove()
+	case "undo":
+		return c.undo()
+	case "new":
+		return c.newGame()
+	case "fen":
+		return c.fen(fields)
+	case "flip":
+		c.flipped = !c.flipped
+		c.printBoard()
+		return nil
+	case "board":
+		c.printBoard()
+		return nil
+	case "hint":
+		return c.hint()
+	case "time":
+		return c.setMoveTime(fields)
+	case "level":
+		return c.setLevel(fields)
+	case "pgn":
+		return c.pgn(fields)
+	case "perft":
+		return c.perft(fields)
+	}
+
+	if _, err := c.parseMove(commandName); err == nil {
+		return c.humanMove(commandName)
+	}
+	return errors.New("unknown command, type help")
+}
+
+func (c *console) current() *common.Position {
+	return &c.positions[len(c.positions)-1]
+}
+
+func (c *console) newGame() error {
+	var p, err = common.NewPositionFromFEN(common.InitialPositionFen)
+	if err != nil {
+		return err
+	}
+	c.setPosition(p)
+	c.engine.Clear()
+	c.printBoard()
+	return nil
+}
+
+func (c *console) setPosition(p common.Position) {
+	c.positions = []common.Position{p}
+	c.engineMoves = []bool{false}
+	c.clocks = [2]int{c.baseTime, c.baseTime}
+	c.result = "*"
+	c.turnStart = time.Now()
+}
+
+func (c *console) parseMove(s string) (common.Position, error) {
+	var p = c.current()
+	if child, ok := p.MakeMoveLAN(s); ok {
+		return child, nil
+	}
+	var move, err = common.ParseSAN(p, s)
+	if err != nil {
+		return common.Position{}, err
+	}
+	var child common.Position
+	p.MakeMove(move, &child)
+	return child, nil
+}
+
+func (c *console) humanMove(s string) error {
+	if c.result != "*" {
+		return errors.New("game over, type new or undo")
+	}
+	var child, err = c.parseMove(s)
+	if err != nil {
+		return err
+	}
+	c.spend(c.current().WhiteMove, time.Since(c.turnStart))
+	c.positions = append(c.positions, child)
+	c.engineMoves = append(c.engineMoves, false)
+	c.printBoard()
+	if c.checkGameOver() {
+		return nil
+	}
+	return c.engineMove()
+}
+
+func (c *console) engineMove() error {
+	if c.result != "*" {
+		return errors.New("game over, type new or undo")
+	}
+	var side = c.current().WhiteMove
+	var start = time.Now()
+	var si = c.engine.Search(context.Background(), common.SearchParams{
+		Positions: c.positions,
+		Limits:    c.limits(),
+	})
+	if len(si.MainLine) == 0 {
+		return errors.New("engine has no move")
+	}
+	c.spend(side, time.Since(start))
+	var move = si.MainLine[0]
+	var child common.Position
+	if !c.current().MakeMove(move, &child) {
+		return fmt.Errorf("engine played illegal move %v", move)
+	}
+	fmt.Fprintf(c.out, "%v plays %v (%v)\n", name, common.MoveToSAN(c.current(), move), formatScore(si))
+	c.positions = append(c.positions, child)
+	c.engineMoves = append(c.engineMoves, true)
+	c.printBoard()
+	c.checkGameOver()
+	return nil
+}
+
+func (c *console) hint() error {
+	if c.result != "*" {
+		return errors.New("game over")
+	}
+	var si = c.engine.Search(context.Background(), common.SearchParams{
+		Positions: c.positions,
+		Limits:    common.LimitsType{MoveTime: common.Min(c.moveTime, 1000)},
+	})
+	if len(si.MainLine) == 0 {
+		return errors.New("no hint")
+	}
+	fmt.Fprintf(c.out, "hint: %v (%v)\n", common.MoveToSAN(c.current(), si.MainLine[0]), formatScore(si))
+	return nil
+}
+
+func (c *console) undo() error {
+	if len(c.positions) <= 1 {
+		return errors.New("no moves to undo")
+	}
+	// take back the engine answer together with the player move
+	var plies = 1
+	if len(c.positions) > 2 && c.engineMoves[len(c.engineMoves)-1] {
+		plies = 2
+	}
+	c.positions = c.positions[:len(c.positions)-plies]
+	c.engineMoves = c.engineMoves[:len(c.engineMoves)-plies]
+	c.result = "*"
+	c.turnStart = time.Now()
+	c.printBoard()
+	return nil
+}
+
+func (c *console) fen(fields []string) error {
+	if len(fields) == 0 {
+		fmt.Fprintln(c.out, c.current())
+		return nil
+	}
+	var p, err = common.NewPositionFromFEN(strings.Join(fields, " "))
+	if err != nil {
+		return err
+	}
+	c.setPosition(p)
+	c.printBoard()
+	return nil
+}
+
+func (c *console) setMoveTime(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("seconds expected")
+	}
+	var seconds, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil || seconds <= 0 {
+		return errors.New("invalid time")
+	}
+	c.moveTime = int(seconds * 1000)
+	c.baseTime = 0
+	c.increment = 0
+	return nil
+}
+
+func (c *console) setLevel(fields []string) error {
+	if len(fields) != 2 {
+		return errors.New("minutes and increment expected")
+	}
+	var minutes, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil || minutes <= 0 {
+		return errors.New("invalid minutes")
+	}
+	inc, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || inc < 0 {
+		return errors.New("invalid increment")
+	}
+	c.baseTime = int(minutes * 60 * 1000)
+	c.increment = int(inc * 1000)
+	c.clocks = [2]int{c.baseTime, c.baseTime}
+	c.turnStart = time.Now()
+	return nil
+}
+
+func (c *console) limits() common.LimitsType {
+	if c.baseTime == 0 {
+		return common.LimitsType{MoveTime: c.moveTime}
+	}
+	return common.LimitsType{
+		WhiteTime:      c.clocks[sideIndex(true)],
+		BlackTime:      c.clocks[sideIndex(false)],
+		WhiteIncrement: c.increment,
+		BlackIncrement: c.increment,
+	}
+}
+
+// spend updates the clock of side after its move
+func (c *console) spend(side bool, elapsed time.Duration) {
+	c.turnStart = time.Now()
+	if c.baseTime == 0 {
+		return
+	}
+	var i = sideIndex(side)
+	c.clocks[i] -= int(elapsed / time.Millisecond)
+	if c.clocks[i] < 0 && c.result == "*" {
+		if side {
+			c.result = "0-1"
+			fmt.Fprintln(c.out, "White lost on time")
+		} else {
+			c.result = "1-0"
+			fmt.Fprintln(c.out, "Black lost on time")
+		}
+		return
+	}
+	c.clocks[i] += c.increment
+}
+
+// checkGameOver reports checkmate and stalemate
+func (c *console) checkGameOver() bool {
+	if c.result != "*" {
+		return true
+	}
+	var outcome = common.GameOutcome(c.positions, true)
+	if !outcome.IsOver() {
+		return false
+	}
+	c.result = outcome.Result
+	fmt.Fprintln(c.out, outcome, c.result)
+	return true
+}
+
+func (c *console) pgn(fields []string) error {
+	var players = [2]string{"Player", "Player"}
+	for i := 1; i < len(c.positions); i++ {
+		if c.engineMoves[i] {
+			players[sideIndex(c.positions[i-1].WhiteMove)] = name + " " + versionName
+		}
+	}
+	var tags = []common.PGNTag{
+		{Name: "Event", Value: "Console game"},
+		{Name: "Site", Value: "?"},
+		{Name: "Date", Value: time.Now().Format("2006.01.02")},
+		{Name: "Round", Value: "-"},
+		{Name: "White", Value: players[sideIndex(true)]},
+		{Name: "Black", Value: players[sideIndex(false)]},
+		{Name: "Result", Value: c.result},
+	}
+	var pgn = common.FormatPGN(tags, c.positions, c.result)
+	if len(fields) == 0 {
+		fmt.Fprint(c.out, pgn)
+		return nil
+	}
+	var err = ioutil.WriteFile(fields[0], []byte(pgn), 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, "saved", fields[0])
+	return nil
+}
+
+func (c *console) perft(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("depth expected")
+	}
+	var depth, err = strconv.Atoi(fields[0])
+	if err != nil || depth < 1 {
+		return errors.New("invalid depth")
+	}
+	var options = common.PerftOptions{
+		Threads: runtime.NumCPU(),
+		Hash:    common.NewPerftHash(64),
+		Stats:   len(fields) > 1 && fields[1] == "stats",
+	}
+	var start = time.Now()
+	var divide = common.PerftDivide(c.current(), depth, options)
+	var elapsed = time.Since(start)
+	fmt.Fprint(c.out, common.FormatPerftDivide(divide, options.Stats))
+	var nodes = 0
+	for _, item := range divide {
+		nodes += item.Stats.Nodes
+	}
+	fmt.Fprintf(c.out, "time %v nps %v\n", elapsed.Round(time.Millisecond), int(float64(nodes)/elapsed.Seconds()))
+	return nil
+}
+
+func (c *console) printBoard() {
+	var p = c.current()
+	var files = "   a b c d e f g h"
+	if c.flipped {
+		files = "   h g f e d c b a"
+	}
+	fmt.Fprintln(c.out, files)
+	for i := 0; i < 8; i++ {
+		var rank = common.Rank8 - i
+		if c.flipped {
+			rank = common.Rank1 + i
+		}
+		fmt.Fprintf(c.out, " %v", rank+1)
+		for j := 0; j < 8; j++ {
+			var file = common.FileA + j
+			if c.flipped {
+				file = common.FileH - j
+			}
+			fmt.Fprintf(c.out, " %c", pieceChar(p, common.MakeSquare(file, rank)))
+		}
+		fmt.Fprintf(c.out, " %v\n", rank+1)
+	}
+	fmt.Fprintln(c.out, files)
+	var sideToMove = "White"
+	if !p.WhiteMove {
+		sideToMove = "Black"
+	}
+	fmt.Fprintf(c.out, "%v to move", sideToMove)
+	if len(c.positions) > 1 {
+		fmt.Fprintf(c.out, ", last move %v", common.MoveToSAN(&c.positions[len(c.positions)-2], p.LastMove))
+	}
+	if p.IsCheck() {
+		fmt.Fprint(c.out, ", check")
+	}
+	fmt.Fprintln(c.out)
+	if c.baseTime != 0 {
+		fmt.Fprintf(c.out, "clocks: White %v Black %v\n",
+			formatClock(c.clocks[sideIndex(true)]), formatClock(c.clocks[sideIndex(false)]))
+	}
+}
+
+func pieceChar(p *common.Position, sq int) byte {
+	var pieceType, side = p.GetPieceTypeAndSide(sq)
+	if pieceType == common.Empty {
+		return '.'
+	}
+	var ch = " pnbrqk"[pieceType]
+	if side {
+		ch -= 'a' - 'A'
+	}
+	return ch
+}
+
+func formatScore(si common.SearchInfo) string {
+	if si.Score.Mate != 0 {
+		return fmt.Sprintf("depth %v mate %v", si.Depth, si.Score.Mate)
+	}
+	return fmt.Sprintf("depth %v score %.2f", si.Depth, float64(si.Score.Centipawns)/100)
+}
+
+func formatClock(ms int) string {
+	if ms < 0 {
+		ms = 0
+	}
+	var seconds = ms / 1000
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+func sideIndex(side bool) int {
+	if side {
+		return 0
+	}
+	return 1
+}
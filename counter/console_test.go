@@ -0,0 +1,109 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/uci/ucitest"
+)
+
+// runConsole plays the script and returns the console output
+func runConsole(t *testing.T, engine *ucitest.Engine, script ...string) string {
+	var out strings.Builder
+	var err = newConsole(engine).Run(strings.NewReader(strings.Join(script, "\n")+"\n"), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func expectOutput(t *testing.T, output string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(output, line) {
+			t.Errorf("%q not in output:\n%v", line, output)
+		}
+	}
+}
+
+func TestConsoleMoves(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var output = runConsole(t, engine, "e4", "move Nf3", "d2d4", "Ke3", "fen")
+	expectOutput(t, output,
+		"Counter plays a6",
+		"Counter plays a5",
+		"Counter plays a4",
+		"error: ",
+		"rnbqkbnr/1ppppppp/8/8/p2PP3/5N2/PPP2PPP/RNBQKB1R w KQkq - 0 4")
+	if n := len(engine.LastParams().Positions); n != 6 {
+		t.Error(n)
+	}
+}
+
+func TestConsoleUndoAndNew(t *testing.T) {
+	var output = runConsole(t, &ucitest.Engine{}, "e4", "undo", "fen", "undo", "e4", "new", "fen")
+	expectOutput(t, output, common.InitialPositionFen+"\n", "error: no moves to undo")
+	if n := strings.Count(output, common.InitialPositionFen); n != 2 {
+		t.Error(n)
+	}
+}
+
+func TestConsoleFen(t *testing.T) {
+	var fen = "7k/6Q1/6K1/8/8/8/8/8 b - - 0 1"
+	var output = runConsole(t, &ucitest.Engine{}, "fen "+fen, "fen", "go", "fen 8/8/8")
+	expectOutput(t, output, fen+"\n", "Black to move, check", "error: engine has no move", "error: invalid fen")
+}
+
+func TestConsoleFlip(t *testing.T) {
+	var output = runConsole(t, &ucitest.Engine{}, "flip")
+	var boards = strings.Split(output, "White to move")
+	if len(boards) != 3 {
+		t.Fatal(output)
+	}
+	if !strings.Contains(boards[1], "   h g f e d c b a\n 1 R N B K Q B N R 1\n") {
+		t.Error(boards[1])
+	}
+}
+
+func TestConsoleHint(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var output = runConsole(t, engine, "hint")
+	expectOutput(t, output, "hint: a3 (depth 5 score 0.15)")
+	if limits := engine.LastParams().Limits; limits.MoveTime != 1000 {
+		t.Error(limits)
+	}
+}
+
+func TestConsoleLevel(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var output = runConsole(t, engine, "level 5 2", "go", "level 5", "level x 2")
+	expectOutput(t, output, "clocks: White 5:0", "error: minutes and increment expected", "error: invalid minutes")
+	var limits = engine.LastParams().Limits
+	if limits.WhiteTime != 300000 || limits.BlackTime != 300000 ||
+		limits.WhiteIncrement != 2000 || limits.MoveTime != 0 {
+		t.Error(limits)
+	}
+	runConsole(t, engine, "time 0.5", "go")
+	if limits := engine.LastParams().Limits; limits.MoveTime != 500 || limits.WhiteTime != 0 {
+		t.Error(limits)
+	}
+}
+
+func TestConsolePGN(t *testing.T) {
+	var file = filepath.Join(t.TempDir(), "game.pgn")
+	var output = runConsole(t, &ucitest.Engine{}, "e4", "Nf3", "pgn", "pgn "+file)
+	expectOutput(t, output,
+		`[White "Player"]`,
+		`[Black "Counter dev"]`,
+		`[Result "*"]`,
+		"1. e4 a6 2. Nf3 a5 *",
+		"saved "+file)
+	var data, err = ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectOutput(t, string(data), "1. e4 a6 2. Nf3 a5 *")
+}
@@ -10,8 +10,15 @@ replace github.com/ChizhovVadim/CounterGo/engine => ../engine
 
 replace github.com/ChizhovVadim/CounterGo/uci => ../uci
 
+replace github.com/ChizhovVadim/CounterGo/xboard => ../xboard
+
+replace github.com/ChizhovVadim/CounterGo/lichess => ../lichess
+
 require (
+	github.com/ChizhovVadim/CounterGo/common v0.0.0-00010101000000-000000000000
 	github.com/ChizhovVadim/CounterGo/engine v0.0.0-00010101000000-000000000000
 	github.com/ChizhovVadim/CounterGo/eval v0.0.0-00010101000000-000000000000
+	github.com/ChizhovVadim/CounterGo/lichess v0.0.0-00010101000000-000000000000
 	github.com/ChizhovVadim/CounterGo/uci v0.0.0-00010101000000-000000000000
+	github.com/ChizhovVadim/CounterGo/xboard v0.0.0-00010101000000-000000000000
 )
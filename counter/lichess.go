@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ChizhovVadim/CounterGo/engine"
+	"github.com/ChizhovVadim/CounterGo/eval"
+	"github.com/ChizhovVadim/CounterGo/lichess"
+	"github.com/ChizhovVadim/CounterGo/uci"
+)
+
+// playLichess plays as a Lichess bot account, the API token is read from LICHESS_BOT_TOKEN
+func playLichess(baseURL string, maxGames int) error {
+	var token = os.Getenv("LICHESS_BOT_TOKEN")
+	if token == "" {
+		return errors.New("LICHESS_BOT_TOKEN is not set")
+	}
+	var bot = &lichess.Bot{
+		BaseURL: baseURL,
+		Token:   token,
+		NewEngine: func() uci.Engine {
+			return engine.NewEngine(func() engine.Evaluator {
+				return eval.NewEvaluationService()
+			})
+		},
+		MaxGames: maxGames,
+		Greeting: fmt.Sprintf("%v %v here, good luck!", name, versionName),
+		Logger:   log.New(os.Stderr, "", log.LstdFlags),
+	}
+	return bot.Run(context.Background())
+}
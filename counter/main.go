@@ -1,12 +1,18 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
+	"strings"
 
 	"github.com/ChizhovVadim/CounterGo/engine"
 	"github.com/ChizhovVadim/CounterGo/eval"
 	"github.com/ChizhovVadim/CounterGo/uci"
+	"github.com/ChizhovVadim/CounterGo/xboard"
 )
 
 /*
@@ -28,27 +34,79 @@ var (
 )
 
 func main() {
+	var listen = flag.String("listen", "", "serve UCI over TCP on this address instead of stdin")
+	var secret = flag.String("secret", "", "shared secret TCP clients must send as \"auth <secret>\"")
+	var maxSessions = flag.Int("maxsessions", 4, "maximum number of concurrent TCP sessions")
+	var lichessBot = flag.Bool("lichess", false, "play as a Lichess bot, the token is read from LICHESS_BOT_TOKEN")
+	var lichessURL = flag.String("lichessurl", "https://lichess.org", "Lichess server address")
+	var maxGames = flag.Int("maxgames", 1, "maximum number of simultaneous Lichess games")
+	flag.Parse()
+
 	fmt.Println(name,
 		"VersionName", versionName,
 		"BuildDate", buildDate,
 		"GitRevision", gitRevision,
 		"RuntimeVersion", runtime.Version())
 
+	var err error
+	if *lichessBot {
+		err = playLichess(*lichessURL, *maxGames)
+	} else if *listen != "" {
+		err = serveTCP(*listen, *secret, *maxSessions)
+	} else {
+		err = run(bufio.NewReader(os.Stdin), os.Stdout, true)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run detects the protocol from the first command: xboard selects CECP,
+// console or a console command (move, board, help...) the console mode, anything else UCI.
+// The console can write files, so it is only offered when allowConsole is set.
+func run(r *bufio.Reader, w io.Writer, allowConsole bool) error {
+	var firstLine, err = r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	var input = io.MultiReader(strings.NewReader(firstLine), r)
+
 	var engine = engine.NewEngine(func() engine.Evaluator {
 		return eval.NewEvaluationService()
 	})
+	var options = []uci.Option{
+		&uci.IntOption{Name: "Hash", Min: 4, Max: 1 << 16, Value: &engine.Hash},
+		&uci.IntOption{Name: "Threads", Min: 1, Max: runtime.NumCPU(), Value: &engine.Threads},
+		&uci.BoolOption{Name: "ExperimentSettings", Value: &engine.ExperimentSettings},
+		&uci.ButtonOption{Name: "Clear Hash", Action: engine.Clear},
+	}
+
+	var firstCommand = strings.Fields(firstLine)
+	if allowConsole && len(firstCommand) != 0 && (firstCommand[0] == "console" || consoleCommands[firstCommand[0]]) {
+		var input io.Reader = r
+		if firstCommand[0] != "console" {
+			input = io.MultiReader(strings.NewReader(firstLine), r)
+		}
+		return newConsole(engine).Run(input, w)
+	}
+
+	if strings.TrimSpace(firstLine) == "xboard" {
+		var protocol = &xboard.Protocol{
+			Name:    name,
+			Version: versionName,
+			Engine:  engine,
+			Options: options,
+		}
+		return protocol.Run(input, w)
+	}
 
 	var protocol = &uci.Protocol{
 		Name:    name,
 		Author:  author,
 		Version: versionName,
 		Engine:  engine,
-		Options: []uci.Option{
-			&uci.IntOption{Name: "Hash", Min: 4, Max: 1 << 16, Value: &engine.Hash},
-			&uci.IntOption{Name: "Threads", Min: 1, Max: runtime.NumCPU(), Value: &engine.Threads},
-			&uci.BoolOption{Name: "ExperimentSettings", Value: &engine.ExperimentSettings},
-		},
+		Options: options,
 	}
-
-	protocol.Run()
+	return protocol.Run(input, w)
 }
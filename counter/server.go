@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"time"
+)
+
+const authTimeout = 10 * time.Second
+
+// serveTCP runs the UCI or CECP command loop for every accepted connection.
+// Each session gets its own engine, so sessions do not share hash tables or threads.
+func serveTCP(addr, secret string, maxSessions int) error {
+	if maxSessions <= 0 {
+		return errors.New("maxsessions must be positive")
+	}
+	var listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	log.Printf("listening on %v", listener.Addr())
+	return serve(listener, secret, maxSessions)
+}
+
+// serve retries temporary accept errors with a growing delay, as net/http does
+func serve(listener net.Listener, secret string, maxSessions int) error {
+	var sessions = make(chan struct{}, maxSessions)
+	var tempDelay time.Duration
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				if tempDelay == 0 {
+					tempDelay = 5 * time.Millisecond
+				} else {
+					tempDelay *= 2
+				}
+				if tempDelay > time.Second {
+					tempDelay = time.Second
+				}
+				log.Printf("accept error: %v; retrying in %v", err, tempDelay)
+				time.Sleep(tempDelay)
+				continue
+			}
+			return err
+		}
+		tempDelay = 0
+		select {
+		case sessions <- struct{}{}:
+			go func() {
+				defer func() { <-sessions }()
+				serveSession(conn, secret)
+			}()
+		default:
+			log.Printf("%v rejected: too many sessions", conn.RemoteAddr())
+			fmt.Fprintln(conn, "info string too many sessions")
+			conn.Close()
+		}
+	}
+}
+
+// serveSession ends when the client sends quit or disconnects.
+// A running search is cancelled in both cases.
+func serveSession(conn net.Conn, secret string) {
+	defer conn.Close()
+	var remote = conn.RemoteAddr()
+	var r = bufio.NewReader(conn)
+	if secret != "" {
+		conn.SetReadDeadline(time.Now().Add(authTimeout))
+		if !authenticate(r, secret) {
+			log.Printf("%v rejected: authentication failed", remote)
+			fmt.Fprintln(conn, "info string authentication failed")
+			return
+		}
+		conn.SetReadDeadline(time.Time{})
+	}
+	log.Printf("%v connected", remote)
+	// remote clients get UCI or CECP only, the console could write files on the server
+	var err = run(r, conn, false)
+	if err != nil {
+		log.Printf("%v %v", remote, err)
+	}
+	log.Printf("%v disconnected", remote)
+}
+
+// authenticate expects the first line to be "auth <secret>",
+// the secret is the rest of the line and may contain spaces
+func authenticate(r *bufio.Reader, secret string) bool {
+	var line, err = r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false
+	}
+	line = strings.TrimRight(line, "\r\n")
+	var index = strings.IndexAny(line, " \t")
+	if index == -1 || line[:index] != "auth" {
+		return false
+	}
+	var value = strings.TrimLeft(line[index:], " \t")
+	return subtle.ConstantTimeCompare([]byte(value), []byte(secret)) == 1
+}
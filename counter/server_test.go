@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthenticate(t *testing.T) {
+	var tests = []struct {
+		line string
+		ok   bool
+	}{
+		{"auth secret\n", true},
+		{"auth secret\r\n", true},
+		{"auth   secret", true},
+		{"auth secret phrase\n", false},
+		{"auth wrong\n", false},
+		{"auth\n", false},
+		{"uci\n", false},
+		{"authsecret\n", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		var r = bufio.NewReader(strings.NewReader(test.line))
+		if ok := authenticate(r, "secret"); ok != test.ok {
+			t.Errorf("%q: got %v want %v", test.line, ok, test.ok)
+		}
+	}
+	var r = bufio.NewReader(strings.NewReader("auth secret phrase\n"))
+	if !authenticate(r, "secret phrase") {
+		t.Error("secret with a space rejected")
+	}
+}
+
+func TestServeTCPMaxSessions(t *testing.T) {
+	if err := serveTCP("127.0.0.1:0", "", 0); err == nil {
+		t.Error("maxsessions 0 accepted")
+	}
+}
+
+// startServer serves sessions on a free local port until the test ends
+func startServer(t *testing.T, secret string, maxSessions int) string {
+	var listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go serve(listener, secret, maxSessions)
+	return listener.Addr().String()
+}
+
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *client {
+	var conn, err = net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	return &client{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (c *client) send(line string) {
+	if _, err := fmt.Fprintln(c.conn, line); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// expect reads lines until one starts with prefix
+func (c *client) expect(prefix string) {
+	for {
+		var line, err = c.r.ReadString('\n')
+		if strings.HasPrefix(line, prefix) {
+			return
+		}
+		if err != nil {
+			c.t.Fatalf("%q not received: %v", prefix, err)
+		}
+	}
+}
+
+func TestServeAuthRejected(t *testing.T) {
+	var addr = startServer(t, "secret", 1)
+	var c = dial(t, addr)
+	c.send("auth wrong")
+	c.expect("info string authentication failed")
+	if _, err := c.r.ReadString('\n'); err == nil {
+		t.Error("connection open after failed authentication")
+	}
+}
+
+func TestServeSessionProtocols(t *testing.T) {
+	var addr = startServer(t, "secret", 2)
+
+	var uci = dial(t, addr)
+	uci.send("auth secret")
+	uci.send("uci")
+	uci.expect("uciok")
+	uci.send("isready")
+	uci.expect("readyok")
+
+	var xboard = dial(t, addr)
+	xboard.send("auth secret")
+	xboard.send("xboard")
+	xboard.send("protover 2")
+	xboard.expect("feature done=1")
+
+	uci.send("quit")
+	xboard.send("quit")
+}
+
+func TestServeSessionLimit(t *testing.T) {
+	var addr = startServer(t, "", 1)
+
+	var first = dial(t, addr)
+	first.send("uci")
+	first.expect("uciok")
+
+	var second = dial(t, addr)
+	second.expect("info string too many sessions")
+
+	first.send("quit")
+	if _, err := first.r.ReadString('\n'); err == nil {
+		t.Error("connection open after quit")
+	}
+	// the slot is freed once the first session has ended
+	var third *client
+	for i := 0; i < 50; i++ {
+		third = dial(t, addr)
+		third.send("isready")
+		var line, _ = third.r.ReadString('\n')
+		if strings.HasPrefix(line, "readyok") {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("session slot not freed")
+}
+
+func TestServeNoConsole(t *testing.T) {
+	var addr = startServer(t, "", 1)
+	var file = filepath.Join(t.TempDir(), "game.pgn")
+	var c = dial(t, addr)
+	c.send("pgn " + file)
+	c.send("isready")
+	c.expect("readyok")
+	c.send("quit")
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Error("console command run over TCP")
+	}
+}
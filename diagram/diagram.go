@@ -0,0 +1,213 @@
+// Package diagram renders chess positions as standalone SVG images
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+)
+
+const squareSize = 45
+
+const (
+	lightColor     = "#f0d9b5"
+	darkColor      = "#b58863"
+	lastMoveColor  = "#9bc700"
+	highlightColor = "#15781b"
+	arrowColor     = "#15781b"
+	checkColor     = "#ff0000"
+)
+
+// Arrow points from one square to another, for example a move of the principal variation
+type Arrow struct {
+	From  int
+	To    int
+	Color string
+}
+
+// Highlight marks a square with a translucent color
+type Highlight struct {
+	Square int
+	Color  string
+}
+
+type Options struct {
+	// Size is the width and height of the image, 360 by default
+	Size        int
+	Flipped     bool
+	Coordinates bool
+	// LastMove highlights the squares of Position.LastMove
+	LastMove   bool
+	Arrows     []Arrow
+	Highlights []Highlight
+}
+
+// Render returns the position as an SVG document.
+// The king in check is marked with a red glow.
+func Render(p *common.Position, options Options) string {
+	var size = options.Size
+	if size <= 0 {
+		size = 8 * squareSize
+	}
+	var sb = &strings.Builder{}
+	fmt.Fprintf(sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v">`+"\n",
+		size, size, 8*squareSize, 8*squareSize)
+	sb.WriteString(`<defs><radialGradient id="check">` +
+		`<stop offset="0%" stop-color="` + checkColor + `" stop-opacity="1"/>` +
+		`<stop offset="50%" stop-color="` + checkColor + `" stop-opacity="0.6"/>` +
+		`<stop offset="100%" stop-color="` + checkColor + `" stop-opacity="0"/>` +
+		`</radialGradient></defs>` + "\n")
+
+	for sq := 0; sq < 64; sq++ {
+		var color = darkColor
+		if (common.File(sq)+common.Rank(sq))%2 != 0 {
+			color = lightColor
+		}
+		writeSquare(sb, sq, options.Flipped, color, 1)
+	}
+
+	if options.LastMove && p.LastMove != common.MoveEmpty {
+		writeSquare(sb, p.LastMove.From(), options.Flipped, lastMoveColor, 0.4)
+		writeSquare(sb, p.LastMove.To(), options.Flipped, lastMoveColor, 0.4)
+	}
+	for _, h := range options.Highlights {
+		writeSquare(sb, h.Square, options.Flipped, colorOrDefault(h.Color, highlightColor), 0.5)
+	}
+	if p.IsCheck() {
+		var x, y = squareOrigin(common.FirstOne(p.Kings&sideMask(p, p.WhiteMove)), options.Flipped)
+		fmt.Fprintf(sb, `<rect x="%v" y="%v" width="%v" height="%v" fill="url(#check)"/>`+"\n",
+			x, y, squareSize, squareSize)
+	}
+	if options.Coordinates {
+		writeCoordinates(sb, options.Flipped)
+	}
+
+	for sq := 0; sq < 64; sq++ {
+		var piece, white = p.GetPieceTypeAndSide(sq)
+		if piece != common.Empty {
+			writePiece(sb, sq, options.Flipped, piece, white)
+		}
+	}
+
+	for _, arrow := range options.Arrows {
+		writeArrow(sb, arrow, options.Flipped)
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+func sideMask(p *common.Position, white bool) uint64 {
+	if white {
+		return p.White
+	}
+	return p.Black
+}
+
+func colorOrDefault(color, defaultColor string) string {
+	if color == "" {
+		return defaultColor
+	}
+	return color
+}
+
+// squareOrigin returns the top left corner of the square
+func squareOrigin(sq int, flipped bool) (int, int) {
+	var col, row = common.File(sq), 7 - common.Rank(sq)
+	if flipped {
+		col, row = 7-col, 7-row
+	}
+	return col * squareSize, row * squareSize
+}
+
+func writeSquare(sb *strings.Builder, sq int, flipped bool, color string, opacity float64) {
+	var x, y = squareOrigin(sq, flipped)
+	fmt.Fprintf(sb, `<rect x="%v" y="%v" width="%v" height="%v" fill="%v"`, x, y, squareSize, squareSize, color)
+	if opacity != 1 {
+		fmt.Fprintf(sb, ` fill-opacity="%v"`, opacity)
+	}
+	sb.WriteString("/>\n")
+}
+
+// writeCoordinates labels files on the bottom rank and ranks on the left file,
+// in the color of the other square color
+func writeCoordinates(sb *strings.Builder, flipped bool) {
+	const files, ranks = "abcdefgh", "12345678"
+	for i := 0; i < 8; i++ {
+		var fileIndex, rankIndex = i, 7 - i
+		if flipped {
+			fileIndex, rankIndex = 7-i, i
+		}
+		var fileColor = darkColor
+		if i%2 == 0 {
+			fileColor = lightColor
+		}
+		fmt.Fprintf(sb, `<text x="%v" y="%v" font-family="sans-serif" font-size="9" fill="%v">%c</text>`+"\n",
+			i*squareSize+squareSize-7, 8*squareSize-2, fileColor, files[fileIndex])
+		var rankColor = lightColor
+		if i%2 == 0 {
+			rankColor = darkColor
+		}
+		fmt.Fprintf(sb, `<text x="%v" y="%v" font-family="sans-serif" font-size="9" fill="%v">%c</text>`+"\n",
+			2, i*squareSize+10, rankColor, ranks[rankIndex])
+	}
+}
+
+// writePiece draws closed glyph paths filled with the piece color and outlined in black,
+// open paths are details drawn in the opposite color
+func writePiece(sb *strings.Builder, sq int, flipped bool, piece int, white bool) {
+	var fill, detail = "#ffffff", "#000000"
+	if !white {
+		fill, detail = "#000000", "#ffffff"
+	}
+	var x, y = squareOrigin(sq, flipped)
+	fmt.Fprintf(sb, `<g transform="translate(%v %v)" stroke-width="1.5" stroke-linejoin="round" stroke-linecap="round">`, x, y)
+	for _, path := range glyphs[piece] {
+		if strings.HasSuffix(path, "z") {
+			fmt.Fprintf(sb, `<path d="%v" fill="%v" stroke="#000000"/>`, path, fill)
+		} else {
+			fmt.Fprintf(sb, `<path d="%v" fill="none" stroke="%v"/>`, path, detail)
+		}
+	}
+	sb.WriteString("</g>\n")
+}
+
+// writeArrow draws the arrow as a polygon from the center of one square to the center of the other
+func writeArrow(sb *strings.Builder, arrow Arrow, flipped bool) {
+	const (
+		shaftWidth = 0.15 * squareSize
+		headWidth  = 0.45 * squareSize
+		headLength = 0.4 * squareSize
+	)
+	var x1, y1 = squareOrigin(arrow.From, flipped)
+	var x2, y2 = squareOrigin(arrow.To, flipped)
+	var fromX, fromY = float64(x1) + squareSize/2, float64(y1) + squareSize/2
+	var toX, toY = float64(x2) + squareSize/2, float64(y2) + squareSize/2
+	var length = math.Hypot(toX-fromX, toY-fromY)
+	if length == 0 {
+		return
+	}
+	// unit direction and normal
+	var ux, uy = (toX - fromX) / length, (toY - fromY) / length
+	var nx, ny = -uy, ux
+	var baseX, baseY = toX - ux*headLength, toY - uy*headLength
+	var points = [][2]float64{
+		{fromX + nx*shaftWidth/2, fromY + ny*shaftWidth/2},
+		{baseX + nx*shaftWidth/2, baseY + ny*shaftWidth/2},
+		{baseX + nx*headWidth/2, baseY + ny*headWidth/2},
+		{toX, toY},
+		{baseX - nx*headWidth/2, baseY - ny*headWidth/2},
+		{baseX - nx*shaftWidth/2, baseY - ny*shaftWidth/2},
+		{fromX - nx*shaftWidth/2, fromY - ny*shaftWidth/2},
+	}
+	sb.WriteString(`<polygon points="`)
+	for i, point := range points {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		fmt.Fprintf(sb, "%.1f,%.1f", point[0], point[1])
+	}
+	fmt.Fprintf(sb, `" fill="%v" fill-opacity="0.8"/>`+"\n", colorOrDefault(arrow.Color, arrowColor))
+}
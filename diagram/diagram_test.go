@@ -0,0 +1,112 @@
+package diagram
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+)
+
+type svgStats struct {
+	rects, pieces, polygons, texts int
+	checks                         int
+}
+
+func parseSVG(t *testing.T, svg string) svgStats {
+	var stats svgStats
+	var decoder = xml.NewDecoder(strings.NewReader(svg))
+	for {
+		var token, err = decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var element, ok = token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch element.Name.Local {
+		case "rect":
+			stats.rects++
+			for _, attr := range element.Attr {
+				if attr.Name.Local == "fill" && attr.Value == "url(#check)" {
+					stats.checks++
+				}
+			}
+		case "g":
+			stats.pieces++
+		case "polygon":
+			stats.polygons++
+		case "text":
+			stats.texts++
+		}
+	}
+	return stats
+}
+
+func TestRender(t *testing.T) {
+	var p, err = common.NewPositionFromFEN(common.InitialPositionFen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var svg = Render(&p, Options{})
+	var stats = parseSVG(t, svg)
+	if stats.rects != 64 || stats.pieces != 32 || stats.polygons != 0 || stats.texts != 0 || stats.checks != 0 {
+		t.Errorf("initial position: %+v", stats)
+	}
+	if strings.Count(svg, "http") != 1 {
+		t.Error("external reference")
+	}
+
+	var child, ok = p.MakeMoveLAN("e2e4")
+	if !ok {
+		t.Fatal("e2e4")
+	}
+	svg = Render(&child, Options{
+		Coordinates: true,
+		LastMove:    true,
+		Arrows:      []Arrow{{From: common.SquareE7, To: common.SquareE5}},
+		Highlights:  []Highlight{{Square: common.SquareD5, Color: "#0000ff"}},
+	})
+	stats = parseSVG(t, svg)
+	if stats.rects != 64+2+1 || stats.polygons != 1 || stats.texts != 16 {
+		t.Errorf("options: %+v", stats)
+	}
+	if !strings.Contains(svg, `fill="#0000ff"`) {
+		t.Error("highlight color")
+	}
+}
+
+func TestRenderCheck(t *testing.T) {
+	var p, err = common.NewPositionFromFEN("rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats = parseSVG(t, Render(&p, Options{}))
+	if stats.checks != 1 {
+		t.Errorf("check: %+v", stats)
+	}
+}
+
+func TestSquareOrigin(t *testing.T) {
+	var tests = []struct {
+		sq      int
+		flipped bool
+		x, y    int
+	}{
+		{common.SquareA1, false, 0, 7 * squareSize},
+		{common.SquareH8, false, 7 * squareSize, 0},
+		{common.SquareA1, true, 7 * squareSize, 0},
+		{common.SquareE4, true, 3 * squareSize, 3 * squareSize},
+	}
+	for _, test := range tests {
+		var x, y = squareOrigin(test.sq, test.flipped)
+		if x != test.x || y != test.y {
+			t.Errorf("%v %v: %v %v, expected %v %v", test.sq, test.flipped, x, y, test.x, test.y)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package diagram
+
+import (
+	"fmt"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+)
+
+// glyphs are piece outlines in a 45x45 box. Closed paths (ending in z) are
+// filled with the piece color and stroked black, open paths are details
+// stroked in the opposite color.
+var glyphs = [common.King + 1][]string{
+	common.Pawn: {
+		circle(22.5, 13, 5),
+		"M19 18.5h7l3.5 13.5h-14z",
+		"M12 32h21v5H12z",
+	},
+	common.Knight: {
+		"M14 37h20c0-7-1-12-3-16c-1.5-3-1.5-6-1.5-10c-3 0-6 1-8.5 3l-7 4.5c-2 1.5-3 3.5-1.5 5.5c1 1.5 3 1.5 4.5 0l3.5-2.5c1 2.5 0 4.5-2 7.5c-2.5 3-4.5 5-4.5 8z",
+		circle(18, 17.5, 1.2),
+	},
+	common.Bishop: {
+		circle(22.5, 7.5, 2.5),
+		"M22.5 10c-5 4-8 9-7 14c1 3 3 5 7 5s6-2 7-5c1-5-2-10-7-14z",
+		"M20 17.5l5 5",
+		"M16 29h13v3H16z",
+		"M11 35c4-1.5 7.5-1.5 11.5 0c4-1.5 7.5-1.5 11.5 0v3c-4-1.5-7.5-1.5-11.5 0c-4-1.5-7.5-1.5-11.5 0z",
+	},
+	common.Rook: {
+		"M13 9h4v3h3V9h5v3h3V9h4v7l-3 3v11l3 3v3H13v-3l3-3V19l-3-3z",
+		"M11 36h23v3H11z",
+		"M16 19h13M16 30h13",
+	},
+	common.Queen: {
+		"M11 35c4-2 19-2 23 0L32 30L35 15L31.5 25L28.5 11L25.5 24L22.5 9L19.5 24L16.5 11L13.5 25L10 15L13 30z",
+		circle(10, 14, 2) + circle(16.5, 10, 2) + circle(22.5, 8, 2) + circle(28.5, 10, 2) + circle(35, 14, 2),
+		"M11 35h23v3H11z",
+	},
+	common.King: {
+		"M21 5h3v3h3v3h-3v5h-3v-5h-3V8h3z",
+		"M12 34c-2.5-6.5 1-13 7-14c2 0 3 1 3.5 2.5c.5-1.5 1.5-2.5 3.5-2.5c6 1 9.5 7.5 7 14z",
+		"M11 34h23v4H11z",
+	},
+}
+
+// circle is a path of two half arcs, so it can be part of a glyph path
+func circle(cx, cy, r float64) string {
+	return fmt.Sprintf("M%g %ga%g %g 0 1 0 %g 0a%g %g 0 1 0 %g 0z", cx-r, cy, r, r, 2*r, r, r, -2*r)
+}
@@ -35,10 +35,12 @@ type thread struct {
 	evaluator Evaluator
 	nodes     int64
 	depth     int32
+	selDepth  int
 	stack     [stackSize]struct {
 		position       Position
-		moveList       [MaxMoves]OrderedMove
-		quietsSearched [MaxMoves]Move
+		moveList       []OrderedMove
+		quietList      []OrderedMove
+		quietsSearched []Move
 		pv             pv
 		staticEval     int
 	}
@@ -50,9 +52,10 @@ type pv struct {
 }
 
 type mainLine struct {
-	moves []Move
-	score int
-	depth int
+	moves    []Move
+	score    int
+	depth    int
+	selDepth int
 }
 
 type TimeManager interface {
@@ -70,6 +73,9 @@ type SortTable interface {
 	Update(p *Position, bestMove Move, searched []Move, depth, height int)
 	Note(p *Position, ml []OrderedMove, trans Move, height int)
 	NoteQS(p *Position, ml []OrderedMove)
+	Killers(height int) (Move, Move)
+	CounterMove(p *Position) Move
+	History(p *Position, move Move) int
 }
 
 type TransTable interface {
@@ -78,6 +84,7 @@ type TransTable interface {
 	Clear()
 	Read(p *Position) (depth, score, bound int, move Move, ok bool)
 	Update(p *Position, depth, score, bound int, move Move)
+	HashFull() int
 }
 
 func NewEngine(evalBuilder func() Evaluator) *Engine {
@@ -124,12 +131,27 @@ func (e *Engine) Search(ctx context.Context, searchParams SearchParams) SearchIn
 		var t = &e.threads[i]
 		t.nodes = 0
 		t.stack[0].position = *p
+		t.allocMoveLists(p.Variant.MaxMoves())
 	}
 	e.progress = searchParams.Progress
 	lazySmp(ctx, e)
 	return e.currentSearchResult()
 }
 
+// allocMoveLists sizes the move buffers of the stack for the variant,
+// so standard chess does not pay for the drops of crazyhouse
+func (t *thread) allocMoveLists(maxMoves int) {
+	if len(t.stack[0].moveList) == maxMoves {
+		return
+	}
+	for i := range t.stack {
+		var s = &t.stack[i]
+		s.moveList = make([]OrderedMove, maxMoves)
+		s.quietList = make([]OrderedMove, maxMoves)
+		s.quietsSearched = make([]Move, maxMoves)
+	}
+}
+
 func getHistoryKeys(positions []Position) map[uint64]int {
 	var result = make(map[uint64]int)
 	for i := len(positions) - 1; i >= 0; i-- {
@@ -155,10 +177,12 @@ func (e *Engine) Clear() {
 func (e *Engine) currentSearchResult() SearchInfo {
 	return SearchInfo{
 		Depth:    e.mainLine.depth,
+		SelDepth: e.mainLine.selDepth,
 		MainLine: e.mainLine.moves,
 		Score:    newUciScore(e.mainLine.score),
 		Nodes:    atomic.LoadInt64(&e.nodes),
 		Time:     int64(time.Since(e.start) / time.Millisecond),
+		HashFull: e.transTable.HashFull(),
 	}
 }
 
@@ -168,6 +192,28 @@ func (e *Engine) sendProgress() {
 	}
 }
 
+// sendCurrMove reports the root move the main thread is searching.
+// It is sent only after currMoveDelay, as UCI GUIs do not need it for short searches.
+func (e *Engine) sendCurrMove(depth int, move Move, moveNumber int) {
+	const currMoveDelay = 3 * time.Second
+	if e.progress == nil {
+		return
+	}
+	var elapsed = time.Since(e.start)
+	if elapsed < currMoveDelay {
+		return
+	}
+	e.mu.Lock()
+	e.progress(SearchInfo{
+		Depth:          depth,
+		Nodes:          atomic.LoadInt64(&e.nodes),
+		Time:           int64(elapsed / time.Millisecond),
+		CurrMove:       move,
+		CurrMoveNumber: moveNumber,
+	})
+	e.mu.Unlock()
+}
+
 func (pv *pv) clear() {
 	pv.size = 0
 }
@@ -0,0 +1,151 @@
+package engine
+
+import . "github.com/ChizhovVadim/CounterGo/common"
+
+const (
+	stageTransMove = iota
+	stageGenerateCaptures
+	stageGoodCaptures
+	stageKiller1
+	stageKiller2
+	stageCounter
+	stageGenerateQuiets
+	stageQuiets
+	stageBadCaptures
+	stageDone
+)
+
+const sortMovesIndex = 4
+
+// movePicker returns the moves of a node stage by stage, a stage is generated only when it is reached:
+// transposition table move, good captures, killers, counter move, quiet moves by history, bad captures.
+type movePicker struct {
+	position  *Position
+	sortTable SortTable
+	transMove Move
+	killer1   Move
+	killer2   Move
+	counter   Move
+	stage     int
+	captures  []OrderedMove
+	quiets    []OrderedMove
+	index     int
+	badCount  int
+}
+
+func (mp *movePicker) Init(p *Position, st SortTable, transMove Move, height int,
+	captures, quiets []OrderedMove) {
+	mp.position = p
+	mp.sortTable = st
+	mp.transMove = transMove
+	mp.killer1, mp.killer2 = st.Killers(height)
+	mp.counter = st.CounterMove(p)
+	mp.stage = stageTransMove
+	mp.captures = captures
+	mp.quiets = quiets
+}
+
+// Next returns MoveEmpty when there are no more moves.
+// Keys of the transposition table move, good captures, killers and counter move are at least sortTableKeyImportant.
+func (mp *movePicker) Next() OrderedMove {
+	var p = mp.position
+	for {
+		switch mp.stage {
+		case stageTransMove:
+			mp.stage++
+			if p.IsPseudoLegal(mp.transMove) {
+				return OrderedMove{Move: mp.transMove, Key: 30000}
+			}
+		case stageGenerateCaptures:
+			mp.captures = p.GenerateCaptures(mp.captures[:cap(mp.captures)])
+			for i := range mp.captures {
+				mp.captures[i].Key = 29000 + mvvlva(mp.captures[i].Move)
+			}
+			mp.index = 0
+			mp.badCount = 0
+			mp.stage++
+		case stageGoodCaptures:
+			for mp.index < len(mp.captures) {
+				moveToTop(mp.captures[mp.index:])
+				var om = mp.captures[mp.index]
+				mp.index++
+				if om.Move == mp.transMove {
+					continue
+				}
+				if !p.SEEGE(om.Move, 0) {
+					om.Key = mp.sortTable.History(p, om.Move)
+					mp.captures[mp.badCount] = om
+					mp.badCount++
+					continue
+				}
+				return om
+			}
+			mp.stage++
+		case stageKiller1:
+			mp.stage++
+			if mp.killer1 != mp.transMove &&
+				p.IsPseudoLegal(mp.killer1) && !isCaptureStageMove(mp.killer1) {
+				return OrderedMove{Move: mp.killer1, Key: 28000}
+			}
+		case stageKiller2:
+			mp.stage++
+			if mp.killer2 != mp.transMove && mp.killer2 != mp.killer1 &&
+				p.IsPseudoLegal(mp.killer2) && !isCaptureStageMove(mp.killer2) {
+				return OrderedMove{Move: mp.killer2, Key: 28000 - 1}
+			}
+		case stageCounter:
+			mp.stage++
+			if mp.counter != mp.transMove && mp.counter != mp.killer1 && mp.counter != mp.killer2 &&
+				p.IsPseudoLegal(mp.counter) && !isCaptureStageMove(mp.counter) {
+				return OrderedMove{Move: mp.counter, Key: 28000 - 2}
+			}
+		case stageGenerateQuiets:
+			var ml = p.GenerateMoves(mp.quiets[:cap(mp.quiets)])
+			var count = 0
+			for i := range ml {
+				var m = ml[i].Move
+				if isCaptureStageMove(m) ||
+					m == mp.transMove || m == mp.killer1 || m == mp.killer2 || m == mp.counter {
+					continue
+				}
+				ml[count] = OrderedMove{Move: m, Key: mp.sortTable.History(p, m)}
+				count++
+			}
+			mp.quiets = ml[:count]
+			mp.index = 0
+			mp.stage++
+		case stageQuiets:
+			if mp.index < len(mp.quiets) {
+				if mp.index < sortMovesIndex {
+					moveToTop(mp.quiets[mp.index:])
+				} else if mp.index == sortMovesIndex {
+					sortMoves(mp.quiets[mp.index:])
+				}
+				var om = mp.quiets[mp.index]
+				mp.index++
+				return om
+			}
+			mp.index = 0
+			mp.stage++
+		case stageBadCaptures:
+			if mp.index < mp.badCount {
+				var om = mp.captures[mp.index]
+				mp.index++
+				return om
+			}
+			mp.stage++
+		default:
+			return OrderedMove{Move: MoveEmpty}
+		}
+	}
+}
+
+// isCaptureStageMove reports whether GenerateCaptures generates the move:
+// captures without underpromotion and queen promotions.
+func isCaptureStageMove(move Move) bool {
+	var promotion = move.Promotion()
+	if move.CapturedPiece() != Empty {
+		return promotion == Empty || promotion == Queen
+	}
+	return promotion == Queen
+}
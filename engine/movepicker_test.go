@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/ChizhovVadim/CounterGo/common"
+)
+
+func TestMovePicker(t *testing.T) {
+	var fens = []string{
+		InitialPositionFen,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+	}
+	var r = rand.New(rand.NewSource(1))
+	var st = &sortTable{}
+	var captures, quiets [MaxMoves]OrderedMove
+	var seenMoves []Move
+	for _, fen := range fens {
+		var p, err = NewPositionFromFEN(fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for ply := 0; ply < 100; ply++ {
+			var legal = p.GenerateLegalMoves()
+			if len(legal) == 0 {
+				break
+			}
+			seenMoves = append(seenMoves, legal...)
+			// killers, counter and transposition table move from other positions
+			var height = ply % 4
+			st.killers[height][0] = seenMoves[r.Intn(len(seenMoves))]
+			st.killers[height][1] = seenMoves[r.Intn(len(seenMoves))]
+			if p.LastMove != MoveEmpty {
+				st.counter[pieceSquareIndex(!p.WhiteMove, p.LastMove)] = seenMoves[r.Intn(len(seenMoves))]
+			}
+			var transMove = seenMoves[r.Intn(len(seenMoves))]
+			if r.Intn(2) == 0 {
+				transMove = legal[r.Intn(len(legal))]
+			}
+
+			var mp movePicker
+			mp.Init(&p, st, transMove, height, captures[:], quiets[:])
+			var picked = make(map[Move]bool)
+			var child Position
+			for {
+				var move = mp.Next().Move
+				if move == MoveEmpty {
+					break
+				}
+				if picked[move] {
+					t.Fatalf("%v: move %v picked twice", p.String(), move)
+				}
+				picked[move] = true
+				if !p.MakeMove(move, &child) {
+					delete(picked, move)
+				}
+			}
+			if len(picked) != len(legal) {
+				t.Fatalf("%v: picked %v legal moves, expected %v", p.String(), len(picked), len(legal))
+			}
+			for _, move := range legal {
+				if !picked[move] {
+					t.Fatalf("%v: move %v not picked", p.String(), move)
+				}
+			}
+			p.MakeMove(legal[r.Intn(len(legal))], &child)
+			p = child
+		}
+	}
+}
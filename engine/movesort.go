@@ -67,7 +67,7 @@ func (st *sortTable) Note(p *Position, ml []OrderedMove, trans Move, height int)
 		if m == trans {
 			score = 30000
 		} else if isCaptureOrPromotion(m) {
-			if seeGEZero(p, m) {
+			if p.SEEGE(m, 0) {
 				score = 29000 + mvvlva(m)
 			} else {
 				score = st.history[pieceSquareIndex(side, m)]
@@ -85,6 +85,21 @@ func (st *sortTable) Note(p *Position, ml []OrderedMove, trans Move, height int)
 	}
 }
 
+func (st *sortTable) Killers(height int) (Move, Move) {
+	return st.killers[height][0], st.killers[height][1]
+}
+
+func (st *sortTable) CounterMove(p *Position) Move {
+	if p.LastMove == MoveEmpty {
+		return MoveEmpty
+	}
+	return st.counter[pieceSquareIndex(!p.WhiteMove, p.LastMove)]
+}
+
+func (st *sortTable) History(p *Position, move Move) int {
+	return st.history[pieceSquareIndex(p.WhiteMove, move)]
+}
+
 func (st *sortTable) NoteQS(p *Position, ml []OrderedMove) {
 	var side = p.WhiteMove
 	for i := range ml {
@@ -108,9 +123,9 @@ func pieceSquareIndex(side bool, move Move) int {
 }
 
 func mvvlva(move Move) int {
-	var captureScore = pieceValuesSEE[move.CapturedPiece()]
+	var captureScore = SEEPieceValues[move.CapturedPiece()]
 	if move.Promotion() != Empty {
-		captureScore += pieceValuesSEE[move.Promotion()] - pieceValuesSEE[Pawn]
+		captureScore += SEEPieceValues[move.Promotion()] - SEEPieceValues[Pawn]
 	}
 	return captureScore*8 - move.MovingPiece()
 }
@@ -78,15 +78,17 @@ func iterativeDeepening(t *thread, ml []Move, startDepth, incDepth int) { //TODO
 			moveToBegin(ml, index)
 		}
 
+		t.selDepth = 0
 		var score, iterationComplete = aspirationWindow(t, ml, depth, globalLine.score)
 		if iterationComplete {
 			t.engine.mu.Lock()
 			if depth > t.engine.mainLine.depth {
 				atomic.StoreInt32(&t.engine.depth, int32(depth))
 				t.engine.mainLine = mainLine{
-					depth: depth,
-					score: score,
-					moves: t.stack[0].pv.toSlice(),
+					depth:    depth,
+					score:    score,
+					moves:    t.stack[0].pv.toSlice(),
+					selDepth: t.selDepth,
 				}
 				t.engine.timeManager.OnIterationComplete(t.engine.mainLine)
 				t.engine.sendProgress()
@@ -126,7 +128,11 @@ func searchRoot(t *thread, ml []Move, alpha, beta, depth int) int {
 	t.stack[height].staticEval = t.evaluator.Evaluate(p)
 	var child = &t.stack[height+1].position
 	var bestMoveIndex = 0
+	var mainThread = t == &t.engine.threads[0]
 	for i, move := range ml {
+		if mainThread {
+			t.engine.sendCurrMove(depth, move, i+1)
+		}
 		p.MakeMove(move, child)
 		var extension, reduction int
 		extension = t.extend(depth, height)
@@ -169,11 +175,20 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 		return valueDraw
 	}
 
+	if position.Variant != VariantStandard {
+		if ended, result := position.VariantEnd(); ended {
+			return variantScore(result, height)
+		}
+	}
+
 	if depth <= 0 {
 		return t.quiescence(alpha, beta, 1, height)
 	}
 
 	t.incNodes()
+	if height > t.selDepth {
+		t.selDepth = height
+	}
 
 	var isCheck = position.IsCheck()
 
@@ -213,9 +228,10 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 		return beta
 	}
 
-	// null-move pruning
+	// null-move pruning, antichess is full of zugzwang
 	var child = &t.stack[height+1].position
 	if !firstline && depth >= 3 && !isCheck && position.LastMove != MoveEmpty &&
+		position.Variant != VariantAntichess &&
 		beta < valueWin && beta > valueLoss &&
 		!(ttHit && ttValue < beta && (ttBound&boundUpper) != 0) &&
 		!isLateEndgame(position, position.WhiteMove) &&
@@ -234,8 +250,7 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 		}
 	}
 
-	var ml = position.GenerateMoves(t.stack[height].moveList[:])
-	t.sortTable.Note(position, ml, ttMove, height)
+	var mp movePicker
 
 	// singular extension
 	var ttMoveIsSingular = false
@@ -245,11 +260,15 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 		ttValue > valueLoss && ttValue < valueWin {
 
 		ttMoveIsSingular = true
-		sortMoves(ml)
 		var singularBeta = Max(-valueInfinity, ttValue-pawnValue/2)
 		newDepth = depth/2 - 1
-		for i := range ml {
-			var move = ml[i].Move
+		mp.Init(position, t.sortTable, ttMove, height,
+			t.stack[height].moveList[:], t.stack[height].quietList[:])
+		for {
+			var move = mp.Next().Move
+			if move == MoveEmpty {
+				break
+			}
 			if !position.MakeMove(move, child) {
 				continue
 			}
@@ -272,20 +291,20 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 	var moveCount = 0
 	var quietsSearched = t.stack[height].quietsSearched[:0]
 	var bestMove Move
-	const SortMovesIndex = 4
 
 	var lmp = 5 + depth*depth
 	if !improving {
 		lmp /= 2
 	}
 
-	for i := range ml {
-		if i < SortMovesIndex {
-			moveToTop(ml[i:])
-		} else if i == SortMovesIndex {
-			sortMoves(ml[i:])
+	mp.Init(position, t.sortTable, ttMove, height,
+		t.stack[height].moveList[:], t.stack[height].quietList[:])
+	for {
+		var om = mp.Next()
+		var move = om.Move
+		if move == MoveEmpty {
+			break
 		}
-		var move = ml[i].Move
 
 		if !position.MakeMove(move, child) {
 			continue
@@ -293,7 +312,7 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 		moveCount++
 
 		if !(alpha <= valueLoss ||
-			ml[i].Key >= sortTableKeyImportant ||
+			om.Key >= sortTableKeyImportant ||
 			isCheck ||
 			child.IsCheck() ||
 			isCaptureOrPromotion(move) ||
@@ -310,8 +329,9 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 				isCheck ||
 				isCaptureOrPromotion(move) ||
 				move == ttMove ||
-				move.MovingPiece() == King) &&
-			!seeGEZero(position, move) {
+				move.MovingPiece() == King ||
+				!useSEE(position)) &&
+			!position.SEEGE(move, 0) {
 			continue
 		}
 
@@ -323,7 +343,7 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 		}
 
 		if depth >= 3 && moveCount > 1 &&
-			!(ml[i].Key >= sortTableKeyImportant ||
+			!(om.Key >= sortTableKeyImportant ||
 				isCaptureOrPromotion(move)) {
 			reduction = t.engine.lateMoveReduction(depth, moveCount)
 			reduction = Max(0, Min(depth-2, reduction))
@@ -357,10 +377,7 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 	}
 
 	if moveCount == 0 {
-		if isCheck {
-			return lossIn(height)
-		}
-		return valueDraw
+		return variantScore(position.NoMovesResult(), height)
 	}
 
 	if bestMove != MoveEmpty && !isCaptureOrPromotion(bestMove) {
@@ -382,12 +399,27 @@ func (t *thread) alphaBeta(alpha, beta, depth, height int, firstline bool) int {
 func (t *thread) quiescence(alpha, beta, depth, height int) int {
 	t.stack[height].pv.clear()
 	t.incNodes()
+	if height > t.selDepth {
+		t.selDepth = height
+	}
 	var position = &t.stack[height].position
 	if height >= maxHeight {
 		return t.evaluator.Evaluate(position)
 	}
+	if position.Variant != VariantStandard {
+		if ended, result := position.VariantEnd(); ended {
+			return variantScore(result, height)
+		}
+	}
 	var isCheck = position.IsCheck()
-	if !isCheck {
+	var ml = t.stack[height].moveList[:]
+	// captures are compulsory in antichess, a single capture is searched without standing pat
+	var forced = false
+	if position.Variant == VariantAntichess {
+		ml = position.GenerateCaptures(ml)
+		forced = len(ml) == 1 && ml[0].Move.CapturedPiece() != Empty
+	}
+	if !isCheck && !forced {
 		var eval = t.evaluator.Evaluate(position)
 		if eval > alpha {
 			alpha = eval
@@ -396,10 +428,9 @@ func (t *thread) quiescence(alpha, beta, depth, height int) int {
 			}
 		}
 	}
-	var ml = t.stack[height].moveList[:]
 	if isCheck {
 		ml = position.GenerateMoves(ml)
-	} else {
+	} else if position.Variant != VariantAntichess {
 		ml = position.GenerateCaptures(ml)
 	}
 	t.sortTable.NoteQS(position, ml)
@@ -408,7 +439,7 @@ func (t *thread) quiescence(alpha, beta, depth, height int) int {
 	var child = &t.stack[height+1].position
 	for i := range ml {
 		var move = ml[i].Move
-		if !isCheck && !seeGEZero(position, move) {
+		if !isCheck && useSEE(position) && !position.SEEGE(move, 0) {
 			continue
 		}
 		if !position.MakeMove(move, child) {
@@ -456,8 +487,12 @@ func isDone(done <-chan struct{}) bool {
 func (t *thread) isDraw(height int) bool {
 	var p = &t.stack[height].position
 
-	if (p.Pawns|p.Rooks|p.Queens) == 0 &&
-		!MoreThanOne(p.Knights|p.Bishops) {
+	if p.Variant == VariantStandard {
+		if (p.Pawns|p.Rooks|p.Queens) == 0 &&
+			!MoreThanOne(p.Knights|p.Bishops) {
+			return true
+		}
+	} else if p.IsInsufficientMaterial() {
 		return true
 	}
 
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/ChizhovVadim/CounterGo/common"
+)
+
+type zeroEvaluator struct{}
+
+func (zeroEvaluator) Evaluate(p *Position) int {
+	return 0
+}
+
+func TestSearchVariantWin(t *testing.T) {
+	var tests = []struct {
+		variant Variant
+		fen     string
+		mate    int
+	}{
+		// the king steps onto the hill
+		{VariantKingOfTheHill, "8/8/8/8/8/4K3/8/k7 w - - 0 1", 1},
+		// the third check wins although it is no mate
+		{VariantThreeCheck, "4k3/8/8/8/8/8/8/R3K3 w - - 1+3 0 1", 1},
+		// every move allows the king to step onto the hill
+		{VariantKingOfTheHill, "8/8/3k4/8/8/8/8/K7 w - - 0 1", -1},
+		// the rook is given away on the a or b file
+		{VariantAntichess, "8/8/8/8/8/8/1r6/R7 w - - 0 1", 1},
+		// the queen explodes the king next to the knight, the check does not matter
+		{VariantAtomic, "3nk3/8/8/Q7/8/8/8/4K2r w - - 0 1", 1},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromVariantFEN(test.variant, test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var e = NewEngine(func() Evaluator { return zeroEvaluator{} })
+		var si = e.Search(context.Background(), SearchParams{
+			Positions: []Position{p},
+			Limits:    LimitsType{Depth: 4},
+		})
+		if si.Score.Mate != test.mate {
+			t.Errorf("%v %v: score %+v %v, expected mate %v", test.variant, test.fen, si.Score, si.MainLine, test.mate)
+		}
+	}
+}
+
+func TestSearchMoveListsFollowVariant(t *testing.T) {
+	var e = NewEngine(func() Evaluator { return zeroEvaluator{} })
+	for _, test := range []struct {
+		variant Variant
+		fen     string
+	}{
+		{VariantStandard, InitialPositionFen},
+		// more than MaxBoardMoves drops
+		{VariantCrazyhouse, "k7/8/8/8/8/8/8/K7[QRBNPqrbnp] w - - 0 1"},
+		{VariantStandard, InitialPositionFen},
+	} {
+		var p, err = NewPositionFromVariantFEN(test.variant, test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var si = e.Search(context.Background(), SearchParams{
+			Positions: []Position{p},
+			Limits:    LimitsType{Nodes: 20000},
+		})
+		if len(si.MainLine) == 0 {
+			t.Errorf("%v %v: no move", test.variant, test.fen)
+		}
+	}
+}
@@ -75,6 +75,23 @@ func (tt *transTable) Clear() {
 	}
 }
 
+// HashFull estimates the permill of entries written in the current search
+func (tt *transTable) HashFull() int {
+	const sampleSize = 1000
+	var size = Min(sampleSize, len(tt.entries))
+	var used = 0
+	for i := 0; i < size; i++ {
+		var entry = &tt.entries[i]
+		if atomic.CompareAndSwapInt32(&entry.gate, 0, 1) {
+			if entry.bound != 0 && entry.Date() == tt.date {
+				used++
+			}
+			atomic.StoreInt32(&entry.gate, 0)
+		}
+	}
+	return used * 1000 / size
+}
+
 func (tt *transTable) Read(p *Position) (depth, score, bound int, move Move, ok bool) {
 	var entry = &tt.entries[uint32(p.Key)&tt.mask]
 	if atomic.CompareAndSwapInt32(&entry.gate, 0, 1) {
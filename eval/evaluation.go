@@ -80,6 +80,11 @@ func (e *EvaluationService) Evaluate(p *Position) int {
 		s            Score
 	)
 
+	// kings may be missing or promoted
+	if p.Variant == VariantAntichess {
+		return evaluateAntichess(p)
+	}
+
 	// init
 
 	var allPieces = p.White | p.Black
@@ -389,6 +394,10 @@ func (e *EvaluationService) Evaluate(p *Position) int {
 		result /= computeFactor(&black, &white, ocb)
 	}
 
+	if p.Variant != VariantStandard {
+		result += evaluateVariant(p)
+	}
+
 	if !p.WhiteMove {
 		result = -result
 	}
@@ -0,0 +1,64 @@
+package eval
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+)
+
+// TestEvaluateMirror checks that evaluation does not depend on the color of the side to move
+func TestEvaluateMirror(t *testing.T) {
+	var e = NewEvaluationService()
+	var r = rand.New(rand.NewSource(1))
+	for game := 0; game < 60; game++ {
+		var variant = common.Variant(game % len(common.VariantNames))
+		var p, err = common.NewPositionFromVariantFEN(variant, variant.StartFEN())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for ply := 0; ply < 200; ply++ {
+			// search does not evaluate a game ended by the variant, an atomic king may be missing
+			if ended, _ := p.VariantEnd(); ended {
+				break
+			}
+			var mirror = common.MirrorPosition(&p)
+			if score, mirrorScore := e.Evaluate(&p), e.Evaluate(&mirror); score != mirrorScore {
+				t.Fatalf("%v: %v, mirrored %v: %v", &p, score, &mirror, mirrorScore)
+			}
+			var ml = p.GenerateLegalMoves()
+			if len(ml) == 0 {
+				break
+			}
+			var child common.Position
+			p.MakeMove(ml[r.Intn(len(ml))], &child)
+			p = child
+		}
+	}
+}
+
+func TestEvaluateVariant(t *testing.T) {
+	var tests = []struct {
+		variant     common.Variant
+		better, fen string
+	}{
+		{common.VariantKingOfTheHill, "4k3/8/8/8/8/4K3/8/8 w - - 0 1", "4k3/8/8/8/8/8/8/4K3 w - - 0 1"},
+		{common.VariantThreeCheck, "4k3/8/8/8/8/8/8/4K3 w - - 1+3 0 1", "4k3/8/8/8/8/8/8/4K3 w - - 2+3 0 1"},
+		{common.VariantAntichess, "4k3/8/8/8/8/8/8/4K3 w - - 0 1", "4k3/8/8/8/8/8/8/3QK3 w - - 0 1"},
+		{common.VariantCrazyhouse, "4k3/8/8/8/8/8/8/4K3[N] w - - 0 1", "4k3/8/8/8/8/8/8/4K3[n] w - - 0 1"},
+	}
+	var e = NewEvaluationService()
+	for _, test := range tests {
+		var better, err = common.NewPositionFromVariantFEN(test.variant, test.better)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := common.NewPositionFromVariantFEN(test.variant, test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Evaluate(&better) <= e.Evaluate(&p) {
+			t.Errorf("%v: %v is not better than %v", test.variant, test.better, test.fen)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package eval
+
+import (
+	. "github.com/ChizhovVadim/CounterGo/common"
+)
+
+var (
+	// checkBonus by the number of checks given
+	checkBonus = [...]int{0, 150, 450}
+	// hillBonus by the distance of the king to the nearest hill square
+	hillBonus = [...]int{0, 250, 100, 40, 10, 0, 0, 0}
+	hill      = [...]int{SquareD4, SquareE4, SquareD5, SquareE5}
+	// pocketValue of a piece in hand, it can be dropped anywhere
+	pocketValue = [...]int{Pawn: 150, Knight: 350, Bishop: 350, Rook: 450, Queen: 900}
+)
+
+// antichessPieceValue is the score of giving a piece away, pieces are worth the same
+const antichessPieceValue = 100
+
+// evaluateVariant returns the score of the variant rules from white's point of view
+func evaluateVariant(p *Position) int {
+	switch p.Variant {
+	case VariantThreeCheck:
+		return checkBonus[Min(p.Checks[0], 2)] - checkBonus[Min(p.Checks[1], 2)]
+	case VariantKingOfTheHill:
+		return hillBonus[hillDistance(FirstOne(p.Kings&p.White))] -
+			hillBonus[hillDistance(FirstOne(p.Kings&p.Black))]
+	case VariantCrazyhouse:
+		var result = 0
+		for piece := Pawn; piece < King; piece++ {
+			result += pocketValue[piece] * (int(p.Pockets[0][piece]) - int(p.Pockets[1][piece]))
+		}
+		return result
+	}
+	return 0
+}
+
+func hillDistance(sq int) int {
+	var result = 7
+	for _, center := range hill {
+		result = Min(result, SquareDistance(sq, center))
+	}
+	return result
+}
+
+// evaluateAntichess returns the score for the side to move, the side with fewer pieces is better
+func evaluateAntichess(p *Position) int {
+	var result = antichessPieceValue * (PopCount(p.Black) - PopCount(p.White))
+	if !p.WhiteMove {
+		result = -result
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package lichess
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+)
+
+type player struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type variant struct {
+	Key string `json:"key"`
+}
+
+// Challenge is an incoming challenge from the event stream
+type Challenge struct {
+	ID          string  `json:"id"`
+	Rated       bool    `json:"rated"`
+	Speed       string  `json:"speed"`
+	Variant     variant `json:"variant"`
+	Challenger  player  `json:"challenger"`
+	InitialFen  string  `json:"initialFen"`
+	TimeControl struct {
+		Type      string `json:"type"`
+		Limit     int    `json:"limit"`
+		Increment int    `json:"increment"`
+	} `json:"timeControl"`
+}
+
+type event struct {
+	Type      string     `json:"type"`
+	Challenge *Challenge `json:"challenge"`
+	Game      *struct {
+		ID     string `json:"id"`
+		GameID string `json:"gameId"`
+	} `json:"game"`
+}
+
+type gameState struct {
+	Type   string `json:"type"`
+	Moves  string `json:"moves"`
+	WTime  int    `json:"wtime"`
+	BTime  int    `json:"btime"`
+	WInc   int    `json:"winc"`
+	BInc   int    `json:"binc"`
+	Status string `json:"status"`
+	Winner string `json:"winner"`
+}
+
+type gameEvent struct {
+	gameState
+	ID         string     `json:"id"`
+	White      player     `json:"white"`
+	Black      player     `json:"black"`
+	InitialFen string     `json:"initialFen"`
+	State      *gameState `json:"state"`
+	Username   string     `json:"username"`
+	Text       string     `json:"text"`
+	Room       string     `json:"room"`
+}
+
+func (b *Bot) newRequest(ctx context.Context, method, path string, form url.Values) (*http.Request, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	var req, err = http.NewRequestWithContext(ctx, method, b.baseURL()+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+b.Token)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return req, nil
+}
+
+func (b *Bot) do(req *http.Request) (*http.Response, error) {
+	var resp, err = b.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg, _ = ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("%v %v: %v %s", req.Method, req.URL.Path, resp.Status, bytes.TrimSpace(msg))
+	}
+	return resp, nil
+}
+
+// post sends a form and discards the response
+func (b *Bot) post(ctx context.Context, path string, form url.Values) error {
+	var req, err = b.newRequest(ctx, http.MethodPost, path, form)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+func (b *Bot) get(ctx context.Context, path string, v interface{}) error {
+	var req, err = b.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// stream reads newline delimited JSON until the stream ends, empty lines are keep alive messages
+func (b *Bot) stream(ctx context.Context, path string, handle func(line []byte) error) error {
+	var req, err = b.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var scanner = bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line = bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := handle(line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+func (b *Bot) accept(ctx context.Context, id string) error {
+	return b.post(ctx, "/api/challenge/"+id+"/accept", nil)
+}
+
+func (b *Bot) decline(ctx context.Context, id, reason string) error {
+	return b.post(ctx, "/api/challenge/"+id+"/decline", url.Values{"reason": {reason}})
+}
+
+func (b *Bot) makeMove(ctx context.Context, gameID string, move common.Move) error {
+	return b.post(ctx, "/api/bot/game/"+gameID+"/move/"+move.String(), nil)
+}
+
+// Chat writes a message to the player or spectator room of a game
+func (b *Bot) Chat(ctx context.Context, gameID, room, text string) error {
+	return b.post(ctx, "/api/bot/game/"+gameID+"/chat", url.Values{"room": {room}, "text": {text}})
+}
@@ -0,0 +1,206 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ChizhovVadim/CounterGo/uci"
+)
+
+const defaultBaseURL = "https://lichess.org"
+
+// Bot plays games through the Lichess Bot API.
+// Every game gets its own engine from NewEngine.
+type Bot struct {
+	BaseURL   string
+	Token     string
+	Client    *http.Client
+	NewEngine func() uci.Engine
+	// MaxGames limits the number of simultaneous games, 1 by default
+	MaxGames int
+	// AcceptChallenge returns an empty string to accept a challenge or a decline reason,
+	// DefaultAcceptChallenge is used when nil
+	AcceptChallenge func(c *Challenge) string
+	Greeting        string
+	Logger          *log.Logger
+	ReconnectDelay  time.Duration
+
+	id string
+	mu sync.Mutex
+	// games holds the running games and, as not started, the accepted challenges,
+	// lichess gives the game the id of the challenge
+	games map[string]bool
+	wg    sync.WaitGroup
+}
+
+// DefaultAcceptChallenge accepts standard chess with a real time clock
+func DefaultAcceptChallenge(c *Challenge) string {
+	if c.Variant.Key != "standard" && c.Variant.Key != "fromPosition" {
+		return "variant"
+	}
+	if c.TimeControl.Type != "clock" {
+		return "timeControl"
+	}
+	return ""
+}
+
+// Run handles the account event stream until ctx is canceled,
+// reconnecting when the stream breaks.
+func (b *Bot) Run(ctx context.Context) error {
+	var account struct {
+		ID string `json:"id"`
+	}
+	if err := b.get(ctx, "/api/account", &account); err != nil {
+		return err
+	}
+	b.id = account.ID
+	b.games = make(map[string]bool)
+	b.logf("connected as %v", b.id)
+	defer b.wg.Wait()
+
+	for {
+		var err = b.stream(ctx, "/api/stream/event", func(line []byte) error {
+			b.handleEvent(ctx, line)
+			return nil
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b.logf("event stream: %v", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(b.reconnectDelay()):
+		}
+	}
+}
+
+func (b *Bot) handleEvent(ctx context.Context, line []byte) {
+	var e event
+	if err := json.Unmarshal(line, &e); err != nil {
+		b.logf("invalid event %s: %v", line, err)
+		return
+	}
+	switch e.Type {
+	case "challenge":
+		if e.Challenge != nil {
+			b.onChallenge(ctx, e.Challenge)
+		}
+	case "challengeCanceled", "challengeDeclined":
+		if e.Challenge != nil {
+			b.release(e.Challenge.ID)
+		}
+	case "gameStart":
+		if e.Game != nil {
+			var id = e.Game.GameID
+			if id == "" {
+				id = e.Game.ID
+			}
+			b.startGame(ctx, id)
+		}
+	default:
+		b.logf("%s", line)
+	}
+}
+
+func (b *Bot) onChallenge(ctx context.Context, c *Challenge) {
+	var reason string
+	if b.AcceptChallenge != nil {
+		reason = b.AcceptChallenge(c)
+	} else {
+		reason = DefaultAcceptChallenge(c)
+	}
+	if reason == "" && !b.reserve(c.ID) {
+		reason = "later"
+	}
+	var err error
+	if reason == "" {
+		b.logf("accept challenge %v from %v", c.ID, c.Challenger.Name)
+		err = b.accept(ctx, c.ID)
+		if err != nil {
+			b.release(c.ID)
+		}
+	} else {
+		b.logf("decline challenge %v from %v: %v", c.ID, c.Challenger.Name, reason)
+		err = b.decline(ctx, c.ID, reason)
+	}
+	if err != nil {
+		b.logf("challenge %v: %v", c.ID, err)
+	}
+}
+
+// reserve counts an accepted challenge as a game, so that challenges arriving together
+// do not exceed MaxGames before their games start
+func (b *Bot) reserve(id string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.games) >= b.maxGames() {
+		return false
+	}
+	b.games[id] = false
+	return true
+}
+
+// release frees the slot of an accepted challenge whose game did not start
+func (b *Bot) release(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if started, ok := b.games[id]; ok && !started {
+		delete(b.games, id)
+	}
+}
+
+func (b *Bot) startGame(ctx context.Context, id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.games[id] {
+		return
+	}
+	b.games[id] = true
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.playGame(ctx, id)
+		b.mu.Lock()
+		delete(b.games, id)
+		b.mu.Unlock()
+	}()
+}
+
+func (b *Bot) baseURL() string {
+	if b.BaseURL == "" {
+		return defaultBaseURL
+	}
+	return b.BaseURL
+}
+
+func (b *Bot) client() *http.Client {
+	if b.Client == nil {
+		return http.DefaultClient
+	}
+	return b.Client
+}
+
+func (b *Bot) maxGames() int {
+	if b.MaxGames <= 0 {
+		return 1
+	}
+	return b.MaxGames
+}
+
+func (b *Bot) reconnectDelay() time.Duration {
+	if b.ReconnectDelay <= 0 {
+		return 5 * time.Second
+	}
+	return b.ReconnectDelay
+}
+
+func (b *Bot) logf(format string, v ...interface{}) {
+	if b.Logger != nil {
+		b.Logger.Printf(format, v...)
+	}
+}
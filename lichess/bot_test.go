@@ -0,0 +1,272 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/uci"
+)
+
+// testEngine plays the first legal move
+type testEngine struct{}
+
+func (e *testEngine) Prepare() {}
+
+func (e *testEngine) Clear() {}
+
+func (e *testEngine) Search(ctx context.Context, searchParams common.SearchParams) common.SearchInfo {
+	var p = &searchParams.Positions[len(searchParams.Positions)-1]
+	var si = common.SearchInfo{Depth: 1}
+	if ml := p.GenerateLegalMoves(); len(ml) != 0 {
+		si.MainLine = []common.Move{ml[0]}
+	}
+	return si
+}
+
+// mockServer implements the part of the Bot API used by the bot.
+// The bot plays white against an opponent who always answers a7a6 and then h7h6,
+// after the opponent's second move the game ends by resignation.
+type mockServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	actions []string
+	moves   chan string
+	done    chan struct{}
+}
+
+func newMockServer(t *testing.T) (*mockServer, *httptest.Server) {
+	var m = &mockServer{
+		t:     t,
+		moves: make(chan string, 10),
+		done:  make(chan struct{}),
+	}
+	return m, httptest.NewServer(m)
+}
+
+func (m *mockServer) record(action string) {
+	m.mu.Lock()
+	m.actions = append(m.actions, action)
+	m.mu.Unlock()
+}
+
+func (m *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var send = func(line string) {
+		fmt.Fprintln(w, line)
+		w.(http.Flusher).Flush()
+	}
+	switch path := r.URL.Path; {
+	case path == "/api/account":
+		send(`{"id":"counterbot","username":"CounterBot"}`)
+	case path == "/api/stream/event":
+		send(`{"type":"challenge","challenge":{"id":"c1","variant":{"key":"atomic"},"challenger":{"id":"a","name":"A"},"timeControl":{"type":"clock","limit":60,"increment":0}}}`)
+		send("")
+		send(`{"type":"challenge","challenge":{"id":"g1","variant":{"key":"standard"},"challenger":{"id":"b","name":"B"},"timeControl":{"type":"clock","limit":60,"increment":1}}}`)
+		send(`{"type":"gameStart","game":{"id":"g1"}}`)
+		<-r.Context().Done()
+	case path == "/api/bot/game/stream/g1":
+		send(`{"type":"gameFull","id":"g1","white":{"id":"counterbot","name":"CounterBot"},"black":{"id":"b","name":"B"},"initialFen":"startpos","state":{"type":"gameState","moves":"","wtime":60000,"btime":60000,"winc":1000,"binc":1000,"status":"started"}}`)
+		var moves []string
+		for _, answer := range []string{"a7a6", "h7h6"} {
+			select {
+			case move := <-m.moves:
+				moves = append(moves, move)
+				send(fmt.Sprintf(`{"type":"gameState","moves":%q,"wtime":59000,"btime":60000,"winc":1000,"binc":1000,"status":"started"}`, strings.Join(moves, " ")))
+				send(`{"type":"chatLine","username":"B","text":"hi","room":"player"}`)
+				moves = append(moves, answer)
+				send(fmt.Sprintf(`{"type":"gameState","moves":%q,"wtime":59000,"btime":59000,"winc":1000,"binc":1000,"status":"started"}`, strings.Join(moves, " ")))
+			case <-time.After(5 * time.Second):
+				m.t.Error("timeout waiting for move")
+				return
+			}
+		}
+		send(fmt.Sprintf(`{"type":"gameState","moves":%q,"wtime":59000,"btime":59000,"winc":1000,"binc":1000,"status":"resign","winner":"white"}`, strings.Join(moves, " ")))
+		close(m.done)
+	case strings.HasPrefix(path, "/api/bot/game/g1/move/"):
+		var move = strings.TrimPrefix(path, "/api/bot/game/g1/move/")
+		m.record("move " + move)
+		m.moves <- move
+		send(`{"ok":true}`)
+	case path == "/api/bot/game/g1/chat":
+		m.record("chat " + r.FormValue("room") + " " + r.FormValue("text"))
+		send(`{"ok":true}`)
+	case strings.HasPrefix(path, "/api/challenge/"):
+		var action = strings.TrimPrefix(path, "/api/challenge/")
+		if reason := r.FormValue("reason"); reason != "" {
+			action += " " + reason
+		}
+		m.record(action)
+		send(`{"ok":true}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestBotPlaysGame(t *testing.T) {
+	var m, server = newMockServer(t)
+	defer server.Close()
+
+	var bot = &Bot{
+		BaseURL:   server.URL,
+		Token:     "secret",
+		NewEngine: func() uci.Engine { return &testEngine{} },
+		Greeting:  "Good luck!",
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	var result = make(chan error, 1)
+	go func() {
+		result <- bot.Run(ctx)
+	}()
+
+	select {
+	case <-m.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("game not finished")
+	}
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Fatal(err)
+	}
+
+	var expected = []string{
+		"c1/decline variant",
+		"g1/accept",
+		"chat player Good luck!",
+		"move a2a3",
+		"move b2b3",
+	}
+	if strings.Join(m.actions, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got %q, expected %q", m.actions, expected)
+	}
+}
+
+// blockingEngine thinks until the search is canceled
+type blockingEngine struct {
+	started  chan struct{}
+	canceled chan struct{}
+}
+
+func (e *blockingEngine) Prepare() {}
+
+func (e *blockingEngine) Clear() {}
+
+func (e *blockingEngine) Search(ctx context.Context, searchParams common.SearchParams) common.SearchInfo {
+	close(e.started)
+	<-ctx.Done()
+	close(e.canceled)
+	return common.SearchInfo{}
+}
+
+func TestGameEndStopsSearch(t *testing.T) {
+	var m, server = newMockServer(t)
+	defer server.Close()
+
+	var engine = &blockingEngine{started: make(chan struct{}), canceled: make(chan struct{})}
+	var bot = &Bot{BaseURL: server.URL, Token: "secret", id: "counterbot"}
+	var g = &game{bot: bot, id: "g1", engine: engine}
+	var ctx = context.Background()
+	var err = g.handleEvent(ctx, []byte(`{"type":"gameFull","id":"g1","white":{"id":"counterbot"},"black":{"id":"b"},"initialFen":"startpos","state":{"type":"gameState","moves":"","wtime":60000,"btime":60000,"status":"started"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-engine.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("search not started")
+	}
+	// the stream is read while the engine thinks
+	err = g.handleEvent(ctx, []byte(`{"type":"gameState","moves":"","wtime":59000,"btime":60000,"status":"aborted"}`))
+	if err != errGameOver {
+		t.Fatal(err)
+	}
+	select {
+	case <-engine.canceled:
+	default:
+		t.Fatal("search not canceled")
+	}
+	if len(m.actions) != 0 {
+		t.Errorf("unexpected actions %q", m.actions)
+	}
+}
+
+func TestBotReservesGameSlots(t *testing.T) {
+	var m, server = newMockServer(t)
+	defer server.Close()
+
+	var bot = &Bot{BaseURL: server.URL, Token: "secret", games: make(map[string]bool)}
+	var wg sync.WaitGroup
+	for _, id := range []string{"c1", "c2"} {
+		var c = &Challenge{ID: id}
+		c.Variant.Key = "standard"
+		c.TimeControl.Type = "clock"
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bot.onChallenge(context.Background(), c)
+		}()
+	}
+	wg.Wait()
+
+	var accepted, declined int
+	for _, action := range m.actions {
+		switch {
+		case strings.HasSuffix(action, "/accept"):
+			accepted++
+		case strings.HasSuffix(action, "/decline later"):
+			declined++
+		}
+	}
+	if accepted != 1 || declined != 1 {
+		t.Errorf("got %q, expected one accepted and one declined challenge", m.actions)
+	}
+
+	// a canceled challenge frees its slot
+	for id := range bot.games {
+		bot.handleEvent(context.Background(), []byte(`{"type":"challengeCanceled","challenge":{"id":"`+id+`"}}`))
+	}
+	if !bot.reserve("c3") {
+		t.Error("slot of the canceled challenge not released")
+	}
+}
+
+func TestBotUnauthorized(t *testing.T) {
+	var _, server = newMockServer(t)
+	defer server.Close()
+
+	var bot = &Bot{
+		BaseURL:   server.URL,
+		Token:     "wrong",
+		NewEngine: func() uci.Engine { return &testEngine{} },
+	}
+	var err = bot.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestDefaultAcceptChallenge(t *testing.T) {
+	var c = &Challenge{}
+	c.Variant.Key = "standard"
+	c.TimeControl.Type = "correspondence"
+	if reason := DefaultAcceptChallenge(c); reason != "timeControl" {
+		t.Errorf("correspondence: %q", reason)
+	}
+	c.TimeControl.Type = "clock"
+	if reason := DefaultAcceptChallenge(c); reason != "" {
+		t.Errorf("standard: %q", reason)
+	}
+	c.Variant.Key = "crazyhouse"
+	if reason := DefaultAcceptChallenge(c); reason != "variant" {
+		t.Errorf("crazyhouse: %q", reason)
+	}
+}
@@ -0,0 +1,156 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/uci"
+)
+
+var errGameOver = errors.New("game over")
+
+type game struct {
+	bot    *Bot
+	id     string
+	engine uci.Engine
+	white  bool
+	start  common.Position
+
+	// the search runs while the stream is read, searchPly is the number of moves it answers
+	searchPly    int
+	cancelSearch context.CancelFunc
+	searchDone   chan struct{}
+}
+
+func (b *Bot) playGame(ctx context.Context, id string) {
+	b.logf("game %v started", id)
+	var g = &game{
+		bot:    b,
+		id:     id,
+		engine: b.NewEngine(),
+	}
+	defer g.stopSearch()
+	var err = b.stream(ctx, "/api/bot/game/stream/"+id, func(line []byte) error {
+		return g.handleEvent(ctx, line)
+	})
+	if err != nil && err != errGameOver && err != io.EOF && ctx.Err() == nil {
+		b.logf("game %v: %v", id, err)
+	}
+	b.logf("game %v finished", id)
+}
+
+func (g *game) handleEvent(ctx context.Context, line []byte) error {
+	var e gameEvent
+	if err := json.Unmarshal(line, &e); err != nil {
+		return err
+	}
+	switch e.Type {
+	case "gameFull":
+		g.white = strings.EqualFold(e.White.ID, g.bot.id)
+		var fen = e.InitialFen
+		if fen == "" || fen == "startpos" {
+			fen = common.InitialPositionFen
+		}
+		var err error
+		g.start, err = common.NewPositionFromFEN(fen)
+		if err != nil {
+			return err
+		}
+		if e.State == nil {
+			return errors.New("gameFull without state")
+		}
+		if g.bot.Greeting != "" && e.State.Moves == "" {
+			g.chat(ctx, g.bot.Greeting)
+		}
+		return g.onState(ctx, e.State)
+	case "gameState":
+		return g.onState(ctx, &e.gameState)
+	case "chatLine":
+		g.bot.logf("game %v %v %v: %v", g.id, e.Room, e.Username, e.Text)
+	}
+	return nil
+}
+
+func (g *game) onState(ctx context.Context, s *gameState) error {
+	if s.Status != "started" && s.Status != "created" {
+		g.stopSearch()
+		g.bot.logf("game %v %v %v", g.id, s.Status, s.Winner)
+		return errGameOver
+	}
+	var positions, err = g.positions(s.Moves)
+	if err != nil {
+		return err
+	}
+	if g.cancelSearch != nil && g.searchPly == len(positions) {
+		// clock updates and draw offers do not restart the search
+		return nil
+	}
+	g.stopSearch()
+	if positions[len(positions)-1].WhiteMove != g.white {
+		return nil
+	}
+	var searchCtx, cancel = context.WithCancel(ctx)
+	g.searchPly = len(positions)
+	g.cancelSearch = cancel
+	g.searchDone = make(chan struct{})
+	go func() {
+		defer close(g.searchDone)
+		if err := g.search(searchCtx, positions, s); err != nil && searchCtx.Err() == nil {
+			g.bot.logf("game %v: %v", g.id, err)
+		}
+	}()
+	return nil
+}
+
+// stopSearch cancels the running search and waits until it returns
+func (g *game) stopSearch() {
+	if g.cancelSearch == nil {
+		return
+	}
+	g.cancelSearch()
+	<-g.searchDone
+	g.cancelSearch = nil
+}
+
+func (g *game) search(ctx context.Context, positions []common.Position, s *gameState) error {
+	var si = g.engine.Search(ctx, common.SearchParams{
+		Positions: positions,
+		Limits: common.LimitsType{
+			WhiteTime:      s.WTime,
+			BlackTime:      s.BTime,
+			WhiteIncrement: s.WInc,
+			BlackIncrement: s.BInc,
+		},
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if len(si.MainLine) == 0 {
+		return errors.New("engine has no move")
+	}
+	return g.bot.makeMove(ctx, g.id, si.MainLine[0])
+}
+
+// positions replays the moves of the game from the start position
+func (g *game) positions(moves string) ([]common.Position, error) {
+	var positions = []common.Position{g.start}
+	for _, lan := range strings.Fields(moves) {
+		var child, ok = positions[len(positions)-1].MakeMoveLAN(lan)
+		if !ok {
+			return nil, fmt.Errorf("illegal move %v", lan)
+		}
+		positions = append(positions, child)
+	}
+	return positions, nil
+}
+
+func (g *game) chat(ctx context.Context, text string) {
+	if err := g.bot.Chat(ctx, g.id, "player", text); err != nil {
+		g.bot.logf("game %v chat: %v", g.id, err)
+	}
+}
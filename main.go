@@ -1,10 +1,14 @@
 package main
 
 import (
+	"errors"
 	"runtime"
 
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/diagram"
 	"github.com/ChizhovVadim/CounterGo/engine"
 	"github.com/ChizhovVadim/CounterGo/eval"
+	"github.com/ChizhovVadim/CounterGo/tactics"
 	"github.com/ChizhovVadim/CounterGo/uci"
 )
 
@@ -36,33 +40,107 @@ func play(args map[string]interface{}) map[string]interface{} {
 			&uci.IntOption{Name: "Hash", Min: 4, Max: 1 << 16, Value: &engine.Hash},
 			&uci.IntOption{Name: "Threads", Min: 1, Max: runtime.NumCPU(), Value: &engine.Threads},
 			&uci.BoolOption{Name: "ExperimentSettings", Value: &engine.ExperimentSettings},
+			&uci.ButtonOption{Name: "Clear Hash", Action: engine.Clear},
 		},
 	}
 
 	res := make(map[string]interface{})
 
 	time := "1000"
-	fen := ""
-	ok := true
 
 	if t, ok := args["time"].(string); ok {
 		time = t
 	}
 
-	fen, ok = args["fen"].(string)
-	if ok {
-		move, err := uci.Play(protocol, fen, time)
-		if err != nil {
-			res = mkMap("error", err.Error())
-		} else {
-			res = mkMap("move", move)
+	positions, err := gamePositions(args)
+	if err != nil {
+		return mkMap("error", err.Error())
+	}
+	if opening, ok := common.ClassifyOpening(positions); ok {
+		res["eco"] = opening.ECO
+		res["opening"] = opening.Name
+		res["variation"] = opening.Variation
+	}
+	// a finished game gets its result instead of a move
+	if outcome := common.GameOutcome(positions, false); outcome.IsOver() {
+		addDiagram(res, args, &positions[len(positions)-1])
+		return outcomeMap(res, outcome)
+	}
+	pos := positions[len(positions)-1]
+	move, err := uci.Play(protocol, pos.String(), time)
+	if err != nil {
+		return mkMap("error", err.Error())
+	}
+	res["move"] = move
+	if child, ok := pos.MakeMoveLAN(move); ok {
+		addDiagram(res, args, &child)
+		if motifs := tactics.AnalyzeMove(&pos, child.LastMove); len(motifs) != 0 {
+			var names []string
+			for _, motif := range motifs {
+				names = append(names, motif.String())
+			}
+			res["tactics"] = names
+		}
+		if outcome := common.GameOutcome(append(positions, child), false); outcome.IsOver() {
+			outcomeMap(res, outcome)
 		}
-	} else {
-		res = mkMap("error", "fen is a required argument")
 	}
 	return res
 }
 
+// gamePositions reads the game from the "pgn" argument
+// or from "moves" played from "fen" (the initial position by default)
+func gamePositions(args map[string]interface{}) ([]common.Position, error) {
+	if pgn, ok := args["pgn"].(string); ok {
+		_, positions, _, err := common.ParsePGN(pgn)
+		return positions, err
+	}
+	fen, hasFen := args["fen"].(string)
+	moves, hasMoves := args["moves"].(string)
+	if !hasFen && !hasMoves {
+		return nil, errors.New("fen, moves or pgn argument is required")
+	}
+	if !hasFen {
+		fen = common.InitialPositionFen
+	}
+	pos, err := common.NewPositionFromFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	return common.ParseMoves(pos, moves)
+}
+
+// addDiagram adds the position as an SVG image when the "svg" argument is set,
+// "flipped" shows the board from the black side
+func addDiagram(res, args map[string]interface{}, pos *common.Position) {
+	if !boolArg(args, "svg") {
+		return
+	}
+	res["svg"] = diagram.Render(pos, diagram.Options{
+		Flipped:     boolArg(args, "flipped"),
+		Coordinates: true,
+		LastMove:    true,
+	})
+}
+
+// boolArg accepts JSON booleans and "true" query strings
+func boolArg(args map[string]interface{}, name string) bool {
+	switch v := args[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	}
+	return false
+}
+
+// outcomeMap adds the result and the reason of a game end
+func outcomeMap(res map[string]interface{}, outcome common.Outcome) map[string]interface{} {
+	res["result"] = outcome.Result
+	res["termination"] = outcome.String()
+	return res
+}
+
 // Main is the entry point of OpenWhisk
 func Main(args map[string]interface{}) map[string]interface{} {
 
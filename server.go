@@ -0,0 +1,198 @@
+// +build server
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/engine"
+	"github.com/ChizhovVadim/CounterGo/eval"
+)
+
+// analysis is a running search that can be stopped by the client
+type analysis struct {
+	cancel context.CancelFunc
+}
+
+type analysisServer struct {
+	mu       sync.Mutex
+	lastID   int64
+	analyses map[string]*analysis
+}
+
+func main() {
+	var addr = flag.String("addr", ":8080", "listen address")
+	flag.Parse()
+
+	var srv = &analysisServer{analyses: make(map[string]*analysis)}
+	http.HandleFunc("/", indexHandler)
+	http.HandleFunc("/analyze", srv.analyzeHandler)
+	http.HandleFunc("/stop", srv.stopHandler)
+	log.Printf("listening on %v", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// serverIndexHTML shows the live analysis controls, which the OpenWhisk action can not serve
+var serverIndexHTML = strings.Replace(indexHTML, "var liveAnalysis = false", "var liveAnalysis = true", 1)
+
+// indexHandler serves the same requests as the OpenWhisk action
+func indexHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, serverIndexHTML)
+	case http.MethodPost:
+		var args = make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(play(args))
+	default:
+		http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+	}
+}
+
+// analyzeHandler streams search progress as server-sent events:
+// "start" with the analysis id, "info" per completed iteration and "bestmove" at the end.
+// The search stops on movetime, on /stop?id=... or when the client disconnects.
+func (srv *analysisServer) analyzeHandler(w http.ResponseWriter, r *http.Request) {
+	var flusher, ok = w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	var p, err = common.NewPositionFromFEN(r.FormValue("fen"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var limits common.LimitsType
+	if s := r.FormValue("time"); s != "" {
+		limits.MoveTime, err = strconv.Atoi(s)
+		if err != nil {
+			http.Error(w, "invalid time", http.StatusBadRequest)
+			return
+		}
+	} else {
+		limits.Infinite = true
+	}
+
+	var ctx, cancel = context.WithCancel(r.Context())
+	defer cancel()
+	var id = srv.add(&analysis{cancel: cancel})
+	defer srv.remove(id)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	writeEvent(w, "start", map[string]interface{}{"id": id})
+	flusher.Flush()
+
+	var eng = engine.NewEngine(func() engine.Evaluator {
+		return eval.NewEvaluationService()
+	})
+	var progress = make(chan common.SearchInfo, 64)
+	var result = make(chan common.SearchInfo, 1)
+	go func() {
+		result <- eng.Search(ctx, common.SearchParams{
+			Positions: []common.Position{p},
+			Limits:    limits,
+			Progress: func(si common.SearchInfo) {
+				if si.CurrMove != common.MoveEmpty {
+					return
+				}
+				select {
+				case progress <- si:
+				default:
+				}
+			},
+		})
+	}()
+
+	for {
+		select {
+		case si := <-progress:
+			writeEvent(w, "info", searchInfoToJSON(si))
+			flusher.Flush()
+		case si := <-result:
+			for len(progress) > 0 {
+				writeEvent(w, "info", searchInfoToJSON(<-progress))
+			}
+			var bestMove = common.MoveEmpty
+			if len(si.MainLine) != 0 {
+				bestMove = si.MainLine[0]
+			}
+			writeEvent(w, "bestmove", map[string]interface{}{"move": bestMove.String()})
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+func (srv *analysisServer) stopHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+		return
+	}
+	srv.mu.Lock()
+	var a = srv.analyses[r.FormValue("id")]
+	srv.mu.Unlock()
+	if a == nil {
+		http.Error(w, "analysis not found", http.StatusNotFound)
+		return
+	}
+	a.cancel()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (srv *analysisServer) add(a *analysis) string {
+	var id = strconv.FormatInt(atomic.AddInt64(&srv.lastID, 1), 10)
+	srv.mu.Lock()
+	srv.analyses[id] = a
+	srv.mu.Unlock()
+	return id
+}
+
+func (srv *analysisServer) remove(id string) {
+	srv.mu.Lock()
+	delete(srv.analyses, id)
+	srv.mu.Unlock()
+}
+
+func writeEvent(w http.ResponseWriter, event string, data interface{}) {
+	var buf, _ = json.Marshal(data)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, buf)
+}
+
+func searchInfoToJSON(si common.SearchInfo) map[string]interface{} {
+	var res = make(map[string]interface{})
+	res["depth"] = si.Depth
+	res["seldepth"] = si.SelDepth
+	if si.Score.Mate != 0 {
+		res["mate"] = si.Score.Mate
+	} else {
+		res["cp"] = si.Score.Centipawns
+	}
+	res["nodes"] = si.Nodes
+	res["time"] = si.Time
+	res["nps"] = si.Nodes * 1000 / (si.Time + 1)
+	res["hashfull"] = si.HashFull
+	var pv = make([]string, len(si.MainLine))
+	for i, move := range si.MainLine {
+		pv[i] = move.String()
+	}
+	res["pv"] = pv
+	return res
+}
@@ -0,0 +1,7 @@
+module github.com/ChizhovVadim/CounterGo/tactics
+
+go 1.15
+
+replace github.com/ChizhovVadim/CounterGo/common => ../common
+
+require github.com/ChizhovVadim/CounterGo/common v0.0.0-00010101000000-000000000000
@@ -0,0 +1,354 @@
+// Package tactics labels moves with the tactical motifs they create
+package tactics
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/ChizhovVadim/CounterGo/common"
+)
+
+type Motif int
+
+const (
+	Fork Motif = iota
+	AbsolutePin
+	RelativePin
+	Skewer
+	DiscoveredAttack
+	DiscoveredCheck
+	DoubleCheck
+	HangingPiece
+	BackRankMate
+	RemovalOfDefender
+	Promotion
+)
+
+var motifNames = [...]string{"fork", "absolutePin", "relativePin", "skewer",
+	"discoveredAttack", "discoveredCheck", "doubleCheck", "hangingPiece",
+	"backRankMate", "removalOfDefender", "promotion"}
+
+func (m Motif) String() string {
+	return motifNames[m]
+}
+
+// Tactic is a motif created by the move at Ply of the analyzed line.
+// Pieces are the squares of the pieces making the motif and Targets the squares of the pieces it hits,
+// a pin or skewer lists the front piece first.
+type Tactic struct {
+	Motif   Motif
+	Ply     int
+	Move    Move
+	Pieces  []int
+	Targets []int
+}
+
+func (t Tactic) String() string {
+	var sb = &strings.Builder{}
+	fmt.Fprintf(sb, "%v %v by %v", t.Motif, t.Move, squareNames(t.Pieces))
+	if len(t.Targets) != 0 {
+		fmt.Fprintf(sb, " on %v", squareNames(t.Targets))
+	}
+	return sb.String()
+}
+
+func squareNames(squares []int) string {
+	var names = make([]string, len(squares))
+	for i, sq := range squares {
+		names[i] = SquareName(sq)
+	}
+	return strings.Join(names, " ")
+}
+
+// AnalyzeLine returns the motifs of every move of a line, for example a principal variation
+func AnalyzeLine(p *Position, line []Move) ([]Tactic, error) {
+	var result []Tactic
+	var pos = *p
+	for ply, move := range line {
+		var child Position
+		if !pos.MakeMove(move, &child) {
+			return nil, fmt.Errorf("illegal move %v at ply %v", move, ply)
+		}
+		for _, tactic := range analyze(&pos, &child, move) {
+			tactic.Ply = ply
+			result = append(result, tactic)
+		}
+		pos = child
+	}
+	return result, nil
+}
+
+// AnalyzeMove returns the motifs of a move, nil for an illegal move
+func AnalyzeMove(p *Position, move Move) []Tactic {
+	var child Position
+	if !p.MakeMove(move, &child) {
+		return nil
+	}
+	return analyze(p, &child, move)
+}
+
+func analyze(p, child *Position, move Move) []Tactic {
+	var result []Tactic
+	var add = func(motif Motif, pieces, targets uint64) {
+		result = append(result, Tactic{
+			Motif:   motif,
+			Move:    move,
+			Pieces:  squares(pieces),
+			Targets: squares(targets),
+		})
+	}
+	var us = p.WhiteMove
+	var to = move.To()
+	var moved = SquareMask[to]
+	if move.MovingPiece() == King && FileDistance(move.From(), to) == 2 {
+		// the castling rook moves too
+		moved |= SquareMask[(move.From()+to)/2]
+	}
+	var king = child.Kings & child.PiecesByColor(!us)
+
+	if move.Promotion() != Empty {
+		add(Promotion, SquareMask[to], 0)
+	}
+	if MoreThanOne(child.Checkers) {
+		add(DoubleCheck, child.Checkers, king)
+	} else if discovered := child.Checkers &^ moved; discovered != 0 {
+		add(DiscoveredCheck, discovered, king)
+	}
+	if isBackRankMate(child) {
+		add(BackRankMate, child.Checkers, king)
+	}
+	for _, t := range discoveredAttacks(p, child, moved) {
+		add(DiscoveredAttack, SquareMask[t.piece], t.targets)
+	}
+	if targets := forkTargets(child, to); targets != 0 {
+		add(Fork, SquareMask[to], targets)
+	}
+	for _, t := range newLineTactics(p, child, moved) {
+		result = append(result, Tactic{
+			Motif:   t.motif,
+			Move:    move,
+			Pieces:  []int{t.piece},
+			Targets: []int{t.front, t.behind},
+		})
+	}
+	if move.CapturedPiece() != Empty {
+		var capturedSq = to
+		if move.MovingPiece() == Pawn && to == p.EpSquare {
+			capturedSq = MakeSquare(File(to), Rank(move.From()))
+		}
+		var occ = (p.White | p.Black) &^ SquareMask[move.From()] &^ SquareMask[capturedSq]
+		if p.AttackersTo(to, occ)&p.PiecesByColor(!us)&occ == 0 {
+			add(HangingPiece, SquareMask[move.From()], SquareMask[capturedSq])
+		}
+		if targets := removedDefenderTargets(p, child, capturedSq, to); targets != 0 {
+			add(RemovalOfDefender, SquareMask[to], targets)
+		}
+	}
+	return result
+}
+
+func squares(b uint64) []int {
+	var result []int
+	for ; b != 0; b &= b - 1 {
+		result = append(result, FirstOne(b))
+	}
+	return result
+}
+
+func pieceAttacks(piece int, side bool, sq int, occ uint64) uint64 {
+	switch piece {
+	case Pawn:
+		return PawnAttacks(sq, side)
+	case Knight:
+		return KnightAttacks[sq]
+	case Bishop:
+		return BishopAttacks(sq, occ)
+	case Rook:
+		return RookAttacks(sq, occ)
+	case Queen:
+		return QueenAttacks(sq, occ)
+	case King:
+		return KingAttacks[sq]
+	}
+	return 0
+}
+
+// isTarget reports whether attacking the piece on sq with piece wins material:
+// the king, a more valuable piece or an undefended one
+func isTarget(p *Position, piece, sq int) bool {
+	var target, side = p.GetPieceTypeAndSide(sq)
+	return target == King ||
+		SEEPieceValues[target] > SEEPieceValues[piece] ||
+		p.Attackers(sq, side) == 0
+}
+
+// isSafe reports whether the side to move can not win material by capturing the piece on sq
+func isSafe(p *Position, sq int) bool {
+	var buffer [MaxMoves]OrderedMove
+	for _, om := range p.GenerateLegal(buffer[:]) {
+		if om.Move.To() == sq && p.SEE(om.Move) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// forkTargets returns the pieces attacked by the moved piece when they are at least two
+func forkTargets(p *Position, sq int) uint64 {
+	var piece, side = p.GetPieceTypeAndSide(sq)
+	var targets uint64
+	for b := pieceAttacks(piece, side, sq, p.White|p.Black) & p.PiecesByColor(!side) &^ p.Pawns; b != 0; b &= b - 1 {
+		var target = FirstOne(b)
+		if isTarget(p, piece, target) {
+			targets |= SquareMask[target]
+		}
+	}
+	if !MoreThanOne(targets) || !isSafe(p, sq) {
+		return 0
+	}
+	return targets
+}
+
+type discovery struct {
+	piece   int
+	targets uint64
+}
+
+// discoveredAttacks returns the sliders that did not move attacking new targets through the vacated square
+func discoveredAttacks(p, child *Position, moved uint64) []discovery {
+	var result []discovery
+	var us = p.WhiteMove
+	var occBefore = p.White | p.Black
+	var occAfter = child.White | child.Black
+	var sliders = (child.Bishops | child.Rooks | child.Queens) & child.PiecesByColor(us) &^ moved
+	for ; sliders != 0; sliders &= sliders - 1 {
+		var sq = FirstOne(sliders)
+		var piece = child.WhatPiece(sq)
+		var opened = pieceAttacks(piece, us, sq, occAfter) &^ pieceAttacks(piece, us, sq, occBefore)
+		var targets uint64
+		for b := opened & child.PiecesByColor(!us) &^ child.Kings; b != 0; b &= b - 1 {
+			var target = FirstOne(b)
+			if isTarget(child, piece, target) {
+				targets |= SquareMask[target]
+			}
+		}
+		if targets != 0 {
+			result = append(result, discovery{sq, targets})
+		}
+	}
+	return result
+}
+
+type lineTactic struct {
+	motif         Motif
+	piece         int
+	front, behind int
+}
+
+// newLineTactics returns the pins and skewers of all sliders of the side that moved
+// that did not exist before the move, so pins opened by a discovery are found too
+func newLineTactics(p, child *Position, moved uint64) []lineTactic {
+	var result []lineTactic
+	var us = p.WhiteMove
+	for sliders := (child.Bishops | child.Rooks | child.Queens) & child.PiecesByColor(us); sliders != 0; sliders &= sliders - 1 {
+		var sq = FirstOne(sliders)
+		var before []lineTactic
+		if (moved & SquareMask[sq]) == 0 {
+			before = lineTactics(p, sq)
+		}
+		for _, t := range lineTactics(child, sq) {
+			if !containsLineTactic(before, t) {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+func containsLineTactic(tactics []lineTactic, t lineTactic) bool {
+	for _, x := range tactics {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+// lineTactics finds pins and skewers of the slider on sq by looking through each attacked piece
+func lineTactics(p *Position, sq int) []lineTactic {
+	var piece, side = p.GetPieceTypeAndSide(sq)
+	if piece != Bishop && piece != Rook && piece != Queen {
+		return nil
+	}
+	var result []lineTactic
+	var occ = p.White | p.Black
+	var enemy = p.PiecesByColor(!side)
+	var attacks = pieceAttacks(piece, side, sq, occ)
+	for b := attacks & enemy; b != 0; b &= b - 1 {
+		var front = FirstOne(b)
+		var xray = pieceAttacks(piece, side, sq, occ&^SquareMask[front]) &^ attacks & enemy
+		if xray == 0 {
+			continue
+		}
+		var behind = FirstOne(xray)
+		var frontPiece, behindPiece = p.WhatPiece(front), p.WhatPiece(behind)
+		switch {
+		case behindPiece == King:
+			result = append(result, lineTactic{AbsolutePin, sq, front, behind})
+		case SEEPieceValues[frontPiece] < SEEPieceValues[behindPiece]:
+			result = append(result, lineTactic{RelativePin, sq, front, behind})
+		case SEEPieceValues[frontPiece] > SEEPieceValues[behindPiece] && behindPiece != Pawn &&
+			isTarget(p, piece, front):
+			result = append(result, lineTactic{Skewer, sq, front, behind})
+		}
+	}
+	return result
+}
+
+// removedDefenderTargets returns the pieces defended by the piece captured on sq
+// that are left attacked and undefended, by other pieces than the capturer on to
+func removedDefenderTargets(p, child *Position, sq, to int) uint64 {
+	var defender, them = p.GetPieceTypeAndSide(sq)
+	var targets uint64
+	for b := pieceAttacks(defender, them, sq, p.White|p.Black) & p.PiecesByColor(them) &^ p.Kings; b != 0; b &= b - 1 {
+		var target = FirstOne(b)
+		if child.Attackers(target, them) == 0 &&
+			child.Attackers(target, !them)&^SquareMask[to] != 0 {
+			targets |= SquareMask[target]
+		}
+	}
+	return targets
+}
+
+// isBackRankMate reports a mate by a rook or queen on the back rank of a king walled in by its own pieces
+func isBackRankMate(p *Position) bool {
+	if p.Checkers == 0 || MoreThanOne(p.Checkers) ||
+		(p.Checkers&(p.Rooks|p.Queens)) == 0 {
+		return false
+	}
+	var backRank, nextRank = Rank1, Rank2
+	if !p.WhiteMove {
+		backRank, nextRank = Rank8, Rank7
+	}
+	var own = p.PiecesByColor(p.WhiteMove)
+	var kingSq = FirstOne(p.Kings & own)
+	if Rank(kingSq) != backRank || Rank(FirstOne(p.Checkers)) != backRank {
+		return false
+	}
+	var blocked = false
+	for b := KingAttacks[kingSq]; b != 0; b &= b - 1 {
+		var sq = FirstOne(b)
+		if Rank(sq) != nextRank {
+			continue
+		}
+		if (own & SquareMask[sq]) == 0 {
+			return false
+		}
+		blocked = true
+	}
+	if !blocked {
+		return false
+	}
+	var buffer [MaxMoves]OrderedMove
+	return len(p.GenerateLegal(buffer[:])) == 0
+}
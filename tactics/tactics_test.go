@@ -0,0 +1,109 @@
+package tactics
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ChizhovVadim/CounterGo/common"
+)
+
+func TestAnalyzeMove(t *testing.T) {
+	var tests = []struct {
+		fen     string
+		san     string
+		tactics []string
+	}{
+		{"r3k3/8/8/3N4/8/8/8/4K3 w - - 0 1", "Nc7+", []string{"fork d5c7 by c7 on a8 e8"}},
+		{"4k3/8/2n5/8/8/8/8/4KB2 w - - 0 1", "Bb5", []string{"absolutePin f1b5 by b5 on c6 e8"}},
+		{"3qk3/8/8/3n4/8/8/8/R3K3 w - - 0 1", "Rd1", []string{"relativePin a1d1 by d1 on d5 d8"}},
+		{"8/1r6/8/3k4/8/8/8/4KB2 w - - 0 1", "Bg2+", []string{"skewer f1g2 by g2 on d5 b7"}},
+		{"4k3/8/8/4q3/8/8/4N3/4R1K1 w - - 0 1", "Nc3", []string{"discoveredAttack e2c3 by e1 on e5"}},
+		{"4k3/8/8/8/8/8/4N3/4R1K1 w - - 0 1", "Nc3+", []string{"discoveredCheck e2c3 by e1 on e8"}},
+		{"4k3/8/8/8/4N3/8/8/4R1K1 w - - 0 1", "Nf6+", []string{"doubleCheck e4f6 by e1 f6 on e8"}},
+		{"4k3/8/8/3n4/8/8/8/3RK3 w - - 0 1", "Rxd5", []string{"hangingPiece d1d5 by d1 on d5"}},
+		{"4k3/8/8/3Pp3/8/8/8/4K3 w - e6 0 1", "dxe6", []string{"hangingPiece d5e6 by d5 on e5"}},
+		{"4k3/8/4n3/8/8/4N3/8/4R1K1 w - - 0 1", "Nc4", []string{"absolutePin e3c4 by e1 on e6 e8"}},
+		{"6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", "Ra8#", []string{"backRankMate a1a8 by a8 on g8"}},
+		{"7k/3b4/5n2/6B1/8/8/8/3QK3 w - - 0 1", "Bxf6+", []string{"removalOfDefender g5f6 by f6 on d7"}},
+		{"8/4P3/8/8/8/8/k7/4K3 w - - 0 1", "e8=Q", []string{"promotion e7e8q by e8"}},
+		{InitialPositionFen, "e4", nil},
+		// the pin was there before the move
+		{"4k3/8/2n5/1B6/8/8/8/4K3 w - - 0 1", "Ke2", nil},
+		// the knight is lost to the bishop
+		{"rb2k3/8/8/3N4/8/8/8/4K3 w - - 0 1", "Nc7+", nil},
+	}
+	for _, test := range tests {
+		var p, err = NewPositionFromFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var move, sanErr = ParseSAN(&p, test.san)
+		if sanErr != nil {
+			t.Fatal(sanErr)
+		}
+		var found = tacticStrings(AnalyzeMove(&p, move))
+		if test.tactics == nil && len(found) != 0 {
+			t.Errorf("%v %v: %v, expected none", test.fen, test.san, found)
+		}
+		for _, expected := range test.tactics {
+			if !contains(found, expected) {
+				t.Errorf("%v %v: %v, expected %v", test.fen, test.san, found, expected)
+			}
+		}
+	}
+}
+
+func TestAnalyzeLine(t *testing.T) {
+	var p, err = NewPositionFromFEN("6k1/5ppp/8/8/8/8/8/R5K1 b - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var positions, movesErr = ParseMoves(p, "Kh8 Ra8#")
+	if movesErr != nil {
+		t.Fatal(movesErr)
+	}
+	var line []Move
+	for _, child := range positions[1:] {
+		line = append(line, child.LastMove)
+	}
+	var tactics, lineErr = AnalyzeLine(&p, line)
+	if lineErr != nil {
+		t.Fatal(lineErr)
+	}
+	if len(tactics) != 1 || tactics[0].Motif != BackRankMate || tactics[0].Ply != 1 ||
+		tactics[0].Targets[0] != SquareH8 {
+		t.Error(tacticStrings(tactics))
+	}
+
+	// the bishop is pinned
+	p, err = NewPositionFromFEN("4r2k/8/8/8/8/8/4B3/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer [MaxMoves]OrderedMove
+	for _, om := range p.GenerateMoves(buffer[:]) {
+		if om.Move.String() == "e2d3" {
+			line = []Move{om.Move}
+		}
+	}
+	if _, lineErr = AnalyzeLine(&p, line); lineErr == nil {
+		t.Error("illegal move accepted")
+	}
+}
+
+func tacticStrings(tactics []Tactic) []string {
+	var result []string
+	for _, tactic := range tactics {
+		result = append(result, tactic.String())
+	}
+	return result
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type Option interface {
@@ -62,3 +63,77 @@ func (opt *IntOption) Set(s string) error {
 	*opt.Value = v
 	return nil
 }
+
+type ComboOption struct {
+	Name   string
+	Values []string
+	Value  *string
+}
+
+func (opt *ComboOption) UciName() string {
+	return opt.Name
+}
+
+func (opt *ComboOption) UciString() string {
+	var sb = &strings.Builder{}
+	fmt.Fprintf(sb, "option name %v type %v default %v",
+		opt.Name, "combo", *opt.Value)
+	for _, v := range opt.Values {
+		fmt.Fprintf(sb, " var %v", v)
+	}
+	return sb.String()
+}
+
+func (opt *ComboOption) Set(s string) error {
+	for _, v := range opt.Values {
+		if strings.EqualFold(v, s) {
+			*opt.Value = v
+			return nil
+		}
+	}
+	return errors.New("unknown option value")
+}
+
+type StringOption struct {
+	Name  string
+	Value *string
+}
+
+func (opt *StringOption) UciName() string {
+	return opt.Name
+}
+
+func (opt *StringOption) UciString() string {
+	var v = *opt.Value
+	if v == "" {
+		v = "<empty>"
+	}
+	return fmt.Sprintf("option name %v type %v default %v",
+		opt.Name, "string", v)
+}
+
+func (opt *StringOption) Set(s string) error {
+	if s == "<empty>" {
+		s = ""
+	}
+	*opt.Value = s
+	return nil
+}
+
+type ButtonOption struct {
+	Name   string
+	Action func()
+}
+
+func (opt *ButtonOption) UciName() string {
+	return opt.Name
+}
+
+func (opt *ButtonOption) UciString() string {
+	return fmt.Sprintf("option name %v type %v", opt.Name, "button")
+}
+
+func (opt *ButtonOption) Set(s string) error {
+	opt.Action()
+	return nil
+}
@@ -1,39 +1,70 @@
 package uci
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/ChizhovVadim/CounterGo/common"
 )
 
 // Play a move
 func Play(uci *Protocol, fen string, time string) (string, error) {
-	var initPosition, err = common.NewPositionFromFEN(fen)
-	if err != nil {
-		return "", err
+	// fen and time are sent as protocol commands and must not inject others
+	if strings.ContainsAny(fen, "\r\n") {
+		return "", errors.New("invalid fen")
 	}
-
-	uci.positions = []common.Position{initPosition}
-	err = uci.isReadyCommand([]string{})
+	var _, err = common.NewPositionFromFEN(fen)
 	if err != nil {
 		return "", err
 	}
-
-	err = uci.goCommand([]string{"movetime", time})
-	if err != nil {
-		return "", err
+	moveTime, err := strconv.Atoi(time)
+	if err != nil || moveTime <= 0 {
+		return "", errors.New("invalid time")
 	}
-	for {
-		searchInfo, ok := <-uci.engineOutput
-		if ok {
-			fmt.Println(searchInfoToUci(searchInfo))
-			if len(searchInfo.MainLine) != 0 {
-				uci.bestMove = searchInfo.MainLine[0]
+
+	var commandReader, commandWriter = io.Pipe()
+	var outputReader, outputWriter = io.Pipe()
+	var done = make(chan error, 1)
+	go func() {
+		var err = uci.Run(commandReader, outputWriter)
+		outputWriter.Close()
+		done <- err
+	}()
+	go fmt.Fprintf(commandWriter, "position fen %v\nisready\ngo movetime %v\n", fen, moveTime)
+
+	// the session ends at the best move or at the first error,
+	// closing the input makes Run return, the output is read until then
+	var bestMove, lastError string
+	var scanner = bufio.NewScanner(outputReader)
+	for scanner.Scan() {
+		var line = scanner.Text()
+		if strings.HasPrefix(line, "info string ") {
+			if lastError == "" && bestMove == "" {
+				lastError = strings.TrimPrefix(line, "info string ")
+				commandWriter.Close()
+			}
+		} else if strings.HasPrefix(line, "bestmove ") {
+			if lastError == "" && bestMove == "" {
+				bestMove = strings.TrimPrefix(line, "bestmove ")
+				commandWriter.Close()
 			}
-		} else {
-			uci.thinking = false
-			uci.engineOutput = nil
-			return uci.bestMove.String(), nil
+		} else if strings.HasPrefix(line, "info ") {
+			fmt.Println(line)
 		}
 	}
+	commandWriter.Close()
+	if err = <-done; err != nil {
+		return "", err
+	}
+	if lastError != "" {
+		return "", errors.New(lastError)
+	}
+	if bestMove == "" {
+		return "", errors.New("search failed")
+	}
+	return bestMove, nil
 }
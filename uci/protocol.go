@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"io"
+	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ChizhovVadim/CounterGo/common"
 )
@@ -29,19 +31,31 @@ type Protocol struct {
 	engineOutput chan common.SearchInfo
 	bestMove     common.Move
 	cancel       context.CancelFunc
+	out          io.Writer
+	debug        bool
+	searchStart  time.Time
+	variant      string
 }
 
-func (uci *Protocol) Run() {
+// Run reads commands from r and writes responses to w
+// until it gets "quit" or the end of input. A running search is stopped before return.
+func (uci *Protocol) Run(r io.Reader, w io.Writer) error {
+	if uci.variant == "" {
+		uci.variant = common.VariantStandard.String()
+	}
 	var initPosition, err = common.NewPositionFromFEN(common.InitialPositionFen)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	uci.positions = []common.Position{initPosition}
+	uci.out = w
 
 	var commands = make(chan string)
+	var scanErr error
 
 	go func() {
-		var scanner = bufio.NewScanner(os.Stdin)
+		defer close(commands)
+		var scanner = bufio.NewScanner(r)
 		for scanner.Scan() {
 			var commandLine = scanner.Text()
 			commands <- commandLine
@@ -49,30 +63,48 @@ func (uci *Protocol) Run() {
 				return
 			}
 		}
+		scanErr = scanner.Err()
 	}()
 
 	for {
 		select {
-		case command := <-commands:
-			if command == "quit" {
-				return
+		case command, ok := <-commands:
+			if !ok || command == "quit" {
+				uci.stopSearch()
+				return scanErr
 			}
 			var err = uci.handleCommand(command)
 			if err != nil {
-				fmt.Println("info string " + err.Error())
+				fmt.Fprintln(uci.out, "info string "+err.Error())
 			}
 		case searchInfo, ok := <-uci.engineOutput:
-			if ok {
-				fmt.Println(searchInfoToUci(searchInfo))
-				if len(searchInfo.MainLine) != 0 {
-					uci.bestMove = searchInfo.MainLine[0]
-				}
-			} else {
-				uci.thinking = false
-				uci.engineOutput = nil
-				fmt.Printf("bestmove %v\n", uci.bestMove)
-			}
+			uci.onEngineOutput(searchInfo, ok)
+		}
+	}
+}
+
+func (uci *Protocol) onEngineOutput(searchInfo common.SearchInfo, ok bool) {
+	if ok {
+		fmt.Fprintln(uci.out, searchInfoToUci(searchInfo))
+		if len(searchInfo.MainLine) != 0 {
+			uci.bestMove = searchInfo.MainLine[0]
 		}
+	} else {
+		uci.thinking = false
+		uci.engineOutput = nil
+		uci.debugf("search finished in %v", time.Since(uci.searchStart))
+		fmt.Fprintf(uci.out, "bestmove %v\n", uci.bestMove)
+	}
+}
+
+func (uci *Protocol) stopSearch() {
+	if !uci.thinking {
+		return
+	}
+	uci.cancel()
+	for uci.thinking {
+		var searchInfo, ok = <-uci.engineOutput
+		uci.onEngineOutput(searchInfo, ok)
 	}
 }
 
@@ -85,9 +117,15 @@ func (uci *Protocol) handleCommand(commandLine string) error {
 	fields = fields[1:]
 
 	if uci.thinking {
-		if commandName == "stop" {
+		switch commandName {
+		case "stop":
 			uci.cancel()
 			return nil
+		case "isready":
+			fmt.Fprintln(uci.out, "readyok")
+			return nil
+		case "debug":
+			return uci.debugCommand(fields)
 		}
 		return errors.New("search still run")
 	}
@@ -98,7 +136,7 @@ func (uci *Protocol) handleCommand(commandLine string) error {
 	case "uci":
 		h = uci.uciCommand
 	case "setoption":
-		h = uci.setOptionCommand
+		return uci.setOptionCommand(commandLine)
 	case "isready":
 		h = uci.isReadyCommand
 	case "position":
@@ -109,6 +147,14 @@ func (uci *Protocol) handleCommand(commandLine string) error {
 		h = uci.uciNewGameCommand
 	case "ponderhit":
 		h = uci.ponderhitCommand
+	case "stop":
+		h = uci.stopCommand
+	case "debug":
+		h = uci.debugCommand
+	case "register":
+		h = uci.registerCommand
+	case "perft":
+		h = uci.perftCommand
 	}
 
 	if h == nil {
@@ -119,22 +165,37 @@ func (uci *Protocol) handleCommand(commandLine string) error {
 }
 
 func (uci *Protocol) uciCommand(fields []string) error {
-	fmt.Printf("id name %s %s\n", uci.Name, uci.Version)
-	fmt.Printf("id author %s\n", uci.Author)
-	for _, option := range uci.Options {
-		fmt.Println(option.UciString())
+	fmt.Fprintf(uci.out, "id name %s %s\n", uci.Name, uci.Version)
+	fmt.Fprintf(uci.out, "id author %s\n", uci.Author)
+	for _, option := range uci.options() {
+		fmt.Fprintln(uci.out, option.UciString())
 	}
-	fmt.Println("uciok")
+	fmt.Fprintln(uci.out, "uciok")
 	return nil
 }
 
-func (uci *Protocol) setOptionCommand(fields []string) error {
-	if len(fields) < 4 {
+// options are the engine options and UCI_Variant, which the protocol handles itself
+func (uci *Protocol) options() []Option {
+	var result = append([]Option(nil), uci.Options...)
+	return append(result, &ComboOption{Name: "UCI_Variant", Values: common.VariantNames[:], Value: &uci.variant})
+}
+
+// setOptionCommand takes the value as the rest of the original line,
+// so values like file paths keep their spacing
+func (uci *Protocol) setOptionCommand(commandLine string) error {
+	var _, rest, ok = cutToken(commandLine, "name")
+	if !ok {
 		return errors.New("invalid setoption arguments")
 	}
-	var name, value = fields[1], fields[3]
-	for _, option := range uci.Options {
+	var name, value = rest, ""
+	if before, after, ok := cutToken(rest, "value"); ok {
+		name = before
+		value = strings.TrimLeft(after, " \t")
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	for _, option := range uci.options() {
 		if strings.EqualFold(option.UciName(), name) {
+			uci.debugf("setoption %v = %v", option.UciName(), value)
 			return option.Set(value)
 		}
 	}
@@ -143,17 +204,24 @@ func (uci *Protocol) setOptionCommand(fields []string) error {
 
 func (uci *Protocol) isReadyCommand(fields []string) error {
 	uci.Engine.Prepare()
-	fmt.Println("readyok")
+	fmt.Fprintln(uci.out, "readyok")
 	return nil
 }
 
 func (uci *Protocol) positionCommand(fields []string) error {
 	var args = fields
+	if len(args) == 0 {
+		return errors.New("invalid position arguments")
+	}
+	var variant, err = common.ParseVariant(uci.variant)
+	if err != nil {
+		return err
+	}
 	var token = args[0]
 	var fen string
 	var movesIndex = findIndexString(args, "moves")
 	if token == "startpos" {
-		fen = common.InitialPositionFen
+		fen = variant.StartFEN()
 	} else if token == "fen" {
 		if movesIndex == -1 {
 			fen = strings.Join(args[1:], " ")
@@ -163,7 +231,7 @@ func (uci *Protocol) positionCommand(fields []string) error {
 	} else {
 		return errors.New("unknown position command")
 	}
-	var p, err = common.NewPositionFromFEN(fen)
+	p, err := common.NewPositionFromVariantFEN(variant, fen)
 	if err != nil {
 		return err
 	}
@@ -178,11 +246,17 @@ func (uci *Protocol) positionCommand(fields []string) error {
 		}
 	}
 	uci.positions = positions
+	uci.debugf("position %v", &positions[len(positions)-1])
 	return nil
 }
 
 func (uci *Protocol) goCommand(fields []string) error {
+	if len(fields) != 0 && fields[0] == "perft" {
+		return uci.perftCommand(fields[1:])
+	}
 	var limits = parseLimits(fields)
+	uci.debugf("go %+v", limits)
+	uci.searchStart = time.Now()
 	var ctx, cancel = context.WithCancel(context.Background())
 	uci.thinking = true
 	uci.bestMove = common.MoveEmpty
@@ -206,6 +280,28 @@ func (uci *Protocol) goCommand(fields []string) error {
 	return nil
 }
 
+// perftCommand handles "perft <depth> [stats]" and "go perft <depth>",
+// it prints the node count of every root move.
+func (uci *Protocol) perftCommand(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("perft depth expected")
+	}
+	var depth, err = strconv.Atoi(fields[0])
+	if err != nil || depth < 1 {
+		return errors.New("invalid perft depth")
+	}
+	var options = common.PerftOptions{
+		Threads: runtime.NumCPU(),
+		Hash:    common.NewPerftHash(16),
+		Stats:   len(fields) > 1 && fields[1] == "stats",
+	}
+	var start = time.Now()
+	var divide = common.PerftDivide(&uci.positions[len(uci.positions)-1], depth, options)
+	fmt.Fprint(uci.out, common.FormatPerftDivide(divide, options.Stats))
+	uci.debugf("perft finished in %v", time.Since(start))
+	return nil
+}
+
 func (uci *Protocol) uciNewGameCommand(fields []string) error {
 	uci.Engine.Clear()
 	return nil
@@ -215,16 +311,58 @@ func (uci *Protocol) ponderhitCommand(fields []string) error {
 	return errors.New("not implemented")
 }
 
+func (uci *Protocol) stopCommand(fields []string) error {
+	// nothing to stop
+	return nil
+}
+
+func (uci *Protocol) debugCommand(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid debug arguments")
+	}
+	switch fields[0] {
+	case "on":
+		uci.debug = true
+	case "off":
+		uci.debug = false
+	default:
+		return errors.New("invalid debug arguments")
+	}
+	uci.debugf("debug %v", fields[0])
+	return nil
+}
+
+func (uci *Protocol) registerCommand(fields []string) error {
+	// registration is not required
+	uci.debugf("register %v", strings.Join(fields, " "))
+	return nil
+}
+
+// debugf sends diagnostics to the GUI in debug mode
+func (uci *Protocol) debugf(format string, args ...interface{}) {
+	if uci.debug {
+		fmt.Fprintf(uci.out, "info string "+format+"\n", args...)
+	}
+}
+
 func searchInfoToUci(si common.SearchInfo) string {
 	var sb = &strings.Builder{}
+	if si.CurrMove != common.MoveEmpty {
+		fmt.Fprintf(sb, "info depth %v currmove %v currmovenumber %v",
+			si.Depth, si.CurrMove, si.CurrMoveNumber)
+		return sb.String()
+	}
 	fmt.Fprintf(sb, "info depth %v", si.Depth)
+	if si.SelDepth != 0 {
+		fmt.Fprintf(sb, " seldepth %v", si.SelDepth)
+	}
 	if si.Score.Mate != 0 {
 		fmt.Fprintf(sb, " score mate %v", si.Score.Mate)
 	} else {
 		fmt.Fprintf(sb, " score cp %v", si.Score.Centipawns)
 	}
 	var nps = si.Nodes * 1000 / (si.Time + 1)
-	fmt.Fprintf(sb, " nodes %v time %v nps %v", si.Nodes, si.Time, nps)
+	fmt.Fprintf(sb, " nodes %v time %v nps %v hashfull %v", si.Nodes, si.Time, nps, si.HashFull)
 	if len(si.MainLine) != 0 {
 		fmt.Fprintf(sb, " pv")
 		for _, move := range si.MainLine {
@@ -237,35 +375,40 @@ func searchInfoToUci(si common.SearchInfo) string {
 
 func parseLimits(args []string) (result common.LimitsType) {
 	for i := 0; i < len(args); i++ {
+		// a missing value reads as an empty string instead of past the end
+		var value string
+		if i+1 < len(args) {
+			value = args[i+1]
+		}
 		switch args[i] {
 		case "ponder":
 			result.Ponder = true
 		case "wtime":
-			result.WhiteTime, _ = strconv.Atoi(args[i+1])
+			result.WhiteTime, _ = strconv.Atoi(value)
 			i++
 		case "btime":
-			result.BlackTime, _ = strconv.Atoi(args[i+1])
+			result.BlackTime, _ = strconv.Atoi(value)
 			i++
 		case "winc":
-			result.WhiteIncrement, _ = strconv.Atoi(args[i+1])
+			result.WhiteIncrement, _ = strconv.Atoi(value)
 			i++
 		case "binc":
-			result.BlackIncrement, _ = strconv.Atoi(args[i+1])
+			result.BlackIncrement, _ = strconv.Atoi(value)
 			i++
 		case "movestogo":
-			result.MovesToGo, _ = strconv.Atoi(args[i+1])
+			result.MovesToGo, _ = strconv.Atoi(value)
 			i++
 		case "depth":
-			result.Depth, _ = strconv.Atoi(args[i+1])
+			result.Depth, _ = strconv.Atoi(value)
 			i++
 		case "nodes":
-			result.Nodes, _ = strconv.Atoi(args[i+1])
+			result.Nodes, _ = strconv.Atoi(value)
 			i++
 		case "mate":
-			result.Mate, _ = strconv.Atoi(args[i+1])
+			result.Mate, _ = strconv.Atoi(value)
 			i++
 		case "movetime":
-			result.MoveTime, _ = strconv.Atoi(args[i+1])
+			result.MoveTime, _ = strconv.Atoi(value)
 			i++
 		case "infinite":
 			result.Infinite = true
@@ -274,6 +417,24 @@ func parseLimits(args []string) (result common.LimitsType) {
 	return
 }
 
+// cutToken splits line around the first whitespace separated token equal to token
+func cutToken(line, token string) (before, after string, found bool) {
+	var i = 0
+	for i < len(line) {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		var start = i
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		if line[start:i] == token {
+			return line[:start], line[i:], true
+		}
+	}
+	return line, "", false
+}
+
 func findIndexString(slice []string, value string) int {
 	for p, v := range slice {
 		if v == value {
@@ -0,0 +1,319 @@
+package uci
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/uci/ucitest"
+)
+
+func newTestSession(t *testing.T, engine Engine, options ...Option) *ucitest.Session {
+	var hash, threads = 16, 1
+	var protocol = &Protocol{
+		Name:    "Counter",
+		Author:  "Vadim Chizhov",
+		Version: "test",
+		Engine:  engine,
+		Options: append([]Option{
+			&IntOption{Name: "Hash", Min: 4, Max: 1 << 16, Value: &hash},
+			&IntOption{Name: "Threads", Min: 1, Max: 4, Value: &threads},
+		}, options...),
+	}
+	return ucitest.NewSession(t, protocol.Run)
+}
+
+func TestUciCommand(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("uci")
+	if line := s.Expect("id name"); line != "id name Counter test" {
+		t.Error(line)
+	}
+	if line := s.Expect("id author"); line != "id author Vadim Chizhov" {
+		t.Error(line)
+	}
+	if line := s.Expect("option name Hash"); line != "option name Hash type spin default 16 min 4 max 65536" {
+		t.Error(line)
+	}
+	s.Expect("option name Threads")
+	s.Expect("uciok")
+	s.Quit()
+}
+
+func TestIsReadyCommand(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	s.Send("isready")
+	s.Expect("readyok")
+	s.Quit()
+	if engine.Prepared() != 1 {
+		t.Error(engine.Prepared())
+	}
+}
+
+func TestPositionAndGo(t *testing.T) {
+	var tests = []struct {
+		command   string
+		positions int
+		fen       string
+	}{
+		{"position startpos", 1, common.InitialPositionFen},
+		{"position startpos moves e2e4 e7e5 g1f3", 4,
+			"rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2"},
+		{"position fen 8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 1,
+			"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1"},
+		{"position fen 8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1 moves e2e4", 2,
+			"8/2p5/3p4/KP5r/1R2Pp1k/8/6P1/8 b - e3 0 1"},
+	}
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	for _, test := range tests {
+		s.Send(test.command)
+		s.Send("go wtime 1000 btime 2000 movestogo 10")
+		s.Expect("info depth 5")
+		var bestMove = strings.TrimPrefix(s.Expect("bestmove "), "bestmove ")
+		var params = engine.LastParams()
+		if len(params.Positions) != test.positions {
+			t.Error(test.command, len(params.Positions))
+			continue
+		}
+		var p = &params.Positions[len(params.Positions)-1]
+		if fen := p.String(); fen != test.fen {
+			t.Error(test.command, fen)
+		}
+		if _, ok := p.MakeMoveLAN(bestMove); !ok {
+			t.Error(test.command, bestMove)
+		}
+		if params.Limits.WhiteTime != 1000 || params.Limits.BlackTime != 2000 ||
+			params.Limits.MovesToGo != 10 {
+			t.Error(test.command, params.Limits)
+		}
+	}
+	s.Quit()
+}
+
+func TestSetOptionCommand(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var ponder = false
+	var style = "Normal"
+	var bookFile = ""
+	var s = newTestSession(t, engine,
+		&BoolOption{Name: "Ponder", Value: &ponder},
+		&ComboOption{Name: "Playing Style", Values: []string{"Solid", "Normal", "Risky"}, Value: &style},
+		&StringOption{Name: "Book File", Value: &bookFile},
+		&ButtonOption{Name: "Clear Hash", Action: engine.Clear},
+	)
+	s.Send("uci")
+	if line := s.Expect("option name Playing Style"); line != "option name Playing Style type combo default Normal var Solid var Normal var Risky" {
+		t.Error(line)
+	}
+	if line := s.Expect("option name Book File"); line != "option name Book File type string default <empty>" {
+		t.Error(line)
+	}
+	if line := s.Expect("option name Clear Hash"); line != "option name Clear Hash type button" {
+		t.Error(line)
+	}
+	s.Expect("uciok")
+	s.Send("setoption name ponder value true")
+	s.Send("setoption name Playing Style value risky")
+	s.Send("setoption name Book File value C:/Program  Files/book.bin")
+	s.Send("setoption name Clear Hash")
+	s.Send("setoption name Playing Style value Crazy")
+	s.Expect("info string unknown option value")
+	s.Send("setoption name Unknown value 1")
+	s.Expect("info string unhandled option")
+	s.Quit()
+	if !ponder {
+		t.Error("Ponder not set")
+	}
+	if style != "Risky" {
+		t.Error(style)
+	}
+	if bookFile != "C:/Program  Files/book.bin" {
+		t.Error(bookFile)
+	}
+	if engine.Cleared() != 1 {
+		t.Error(engine.Cleared())
+	}
+}
+
+func TestVariantOption(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	s.Send("uci")
+	if line := s.Expect("option name UCI_Variant"); line != "option name UCI_Variant type combo default chess var chess var 3check var kingofthehill var antichess var crazyhouse var atomic" {
+		t.Error(line)
+	}
+	s.Expect("uciok")
+	s.Send("setoption name UCI_Variant value 3check")
+	s.Send("position startpos moves e2e4 f7f6 d1h5")
+	s.Send("go movetime 10")
+	s.Expect("bestmove ")
+	var params = engine.LastParams()
+	var p = &params.Positions[len(params.Positions)-1]
+	if fen := p.String(); fen != "rnbqkbnr/ppppp1pp/5p2/7Q/4P3/8/PPPP1PPP/RNB1KBNR b KQkq - 2+3 1 2" {
+		t.Error(fen)
+	}
+	s.Send("setoption name UCI_Variant value kingofthehill")
+	s.Send("position fen 8/8/8/8/8/4K3/8/k7 w - - 0 1")
+	s.Send("perft 2")
+	s.Expect("Nodes searched: 18")
+	s.Send("setoption name UCI_Variant value bughouse")
+	s.Expect("info string unknown option value")
+	s.Quit()
+}
+
+func TestInvalidPosition(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("position")
+	s.Expect("info string")
+	s.Send("position startpos moves e2e5")
+	s.Expect("info string")
+	s.Send("position fen 8/8/8")
+	s.Expect("info string")
+	s.Quit()
+}
+
+func TestPerftCommand(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("position startpos moves e2e4")
+	s.Send("go perft 2")
+	if line := s.Expect("a7a6"); line != "a7a6: 30" {
+		t.Error(line)
+	}
+	if line := s.Expect("Nodes searched"); line != "Nodes searched: 600" {
+		t.Error(line)
+	}
+	s.Send("position startpos")
+	s.Send("perft 3 stats")
+	s.Expect("Nodes searched: 8902")
+	if line := s.Expect("nodes"); line != "nodes 8902 captures 34 ep 0 castles 0 promotions 0 checks 12 checkmates 0" {
+		t.Error(line)
+	}
+	s.Send("perft x")
+	s.Expect("info string invalid perft depth")
+	s.Quit()
+}
+
+func TestGoStop(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("position startpos")
+	s.Send("go infinite")
+	s.Expect("info depth")
+	s.Send("isready")
+	s.Expect("readyok")
+	s.Send("position startpos moves e2e4")
+	s.Expect("info string search still run")
+	s.Send("stop")
+	s.Expect("bestmove ")
+	s.Send("isready")
+	s.Expect("readyok")
+	s.Quit()
+}
+
+func TestDebugCommand(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("stop")
+	s.Send("register later")
+	s.Send("isready")
+	if line := s.Expect(""); line != "readyok" {
+		t.Error(line)
+	}
+	s.Send("debug on")
+	if line := s.Expect(""); line != "info string debug on" {
+		t.Error(line)
+	}
+	s.Send("position startpos moves e2e4")
+	if line := s.Expect(""); line != "info string position rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1" {
+		t.Error(line)
+	}
+	s.Send("debug off")
+	s.Send("position startpos")
+	s.Send("isready")
+	if line := s.Expect(""); line != "readyok" {
+		t.Error(line)
+	}
+	s.Send("debug")
+	s.Expect("info string invalid debug arguments")
+	s.Quit()
+}
+
+func TestSearchInfoToUci(t *testing.T) {
+	var p, _ = common.NewPositionFromFEN(common.InitialPositionFen)
+	var e2e4, _ = p.MakeMoveLAN("e2e4")
+	var tests = []struct {
+		si  common.SearchInfo
+		uci string
+	}{
+		{common.SearchInfo{Depth: 12, SelDepth: 18, Score: common.UciScore{Centipawns: 25},
+			Nodes: 1000, Time: 999, HashFull: 42, MainLine: []common.Move{e2e4.LastMove}},
+			"info depth 12 seldepth 18 score cp 25 nodes 1000 time 999 nps 1000 hashfull 42 pv e2e4"},
+		{common.SearchInfo{Depth: 7, Score: common.UciScore{Mate: -3}},
+			"info depth 7 score mate -3 nodes 0 time 0 nps 0 hashfull 0"},
+		{common.SearchInfo{Depth: 20, CurrMove: e2e4.LastMove, CurrMoveNumber: 3},
+			"info depth 20 currmove e2e4 currmovenumber 3"},
+	}
+	for _, test := range tests {
+		if line := searchInfoToUci(test.si); line != test.uci {
+			t.Error(line)
+		}
+	}
+}
+
+func TestEndOfInputStopsSearch(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("go infinite")
+	s.Expect("info depth")
+	s.CloseInput()
+	s.Expect("bestmove ")
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	var protocol = &Protocol{Engine: &ucitest.Engine{}}
+	var move, err = Play(protocol, "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", "100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if move == "" || move == "0000" {
+		t.Error(move)
+	}
+	var invalid = []struct{ fen, time string }{
+		{"8/8/8", "100"},
+		{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1\nquit", "100"},
+		{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", ""},
+		{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", "100\nquit"},
+		{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", "-5"},
+	}
+	for _, test := range invalid {
+		if _, err = Play(protocol, test.fen, test.time); err == nil {
+			t.Errorf("%q %q accepted", test.fen, test.time)
+		}
+	}
+}
+
+func TestPlayProtocolError(t *testing.T) {
+	var protocol = &Protocol{Engine: &ucitest.Engine{}, variant: "bughouse"}
+	var done = make(chan error, 1)
+	go func() {
+		var _, err = Play(protocol, common.InitialPositionFen, "100")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("error not reported")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Play did not return")
+	}
+}
+
+func TestParseLimitsMissingValue(t *testing.T) {
+	if limits := parseLimits([]string{"wtime", "1000", "movetime"}); limits.WhiteTime != 1000 || limits.MoveTime != 0 {
+		t.Error(limits)
+	}
+}
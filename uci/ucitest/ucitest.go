@@ -0,0 +1,159 @@
+// Package ucitest provides a scripted engine and a session driver
+// for testing the UCI and CECP protocol loops.
+package ucitest
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+)
+
+const timeout = 5 * time.Second
+
+// Engine plays the first legal move.
+// Infinite searches run until they are cancelled.
+type Engine struct {
+	mu       sync.Mutex
+	prepared int
+	cleared  int
+	params   common.SearchParams
+}
+
+func (e *Engine) Prepare() {
+	e.mu.Lock()
+	e.prepared++
+	e.mu.Unlock()
+}
+
+func (e *Engine) Clear() {
+	e.mu.Lock()
+	e.cleared++
+	e.mu.Unlock()
+}
+
+func (e *Engine) Search(ctx context.Context, searchParams common.SearchParams) common.SearchInfo {
+	e.mu.Lock()
+	e.params = searchParams
+	e.mu.Unlock()
+	var p = &searchParams.Positions[len(searchParams.Positions)-1]
+	var si = common.SearchInfo{Depth: 5, Score: common.UciScore{Centipawns: 15}, Nodes: 100, Time: 250}
+	if ml := p.GenerateLegalMoves(); len(ml) != 0 {
+		si.MainLine = ml[:1]
+	}
+	if searchParams.Progress != nil {
+		searchParams.Progress(si)
+	}
+	if searchParams.Limits.Infinite {
+		<-ctx.Done()
+	}
+	return si
+}
+
+// LastParams returns the parameters of the latest search
+func (e *Engine) LastParams() common.SearchParams {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params
+}
+
+func (e *Engine) Prepared() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.prepared
+}
+
+func (e *Engine) Cleared() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.cleared
+}
+
+// Session feeds commands to a protocol loop and reads its output line by line
+type Session struct {
+	t      *testing.T
+	input  *io.PipeWriter
+	output chan string
+	done   chan error
+}
+
+// NewSession starts run in the background, run is usually the Run method of a protocol
+func NewSession(t *testing.T, run func(r io.Reader, w io.Writer) error) *Session {
+	var inputReader, inputWriter = io.Pipe()
+	var outputReader, outputWriter = io.Pipe()
+	var s = &Session{
+		t:      t,
+		input:  inputWriter,
+		output: make(chan string, 100),
+		done:   make(chan error, 1),
+	}
+	go func() {
+		var err = run(inputReader, outputWriter)
+		outputWriter.Close()
+		s.done <- err
+	}()
+	go func() {
+		defer close(s.output)
+		var scanner = bufio.NewScanner(outputReader)
+		for scanner.Scan() {
+			s.output <- scanner.Text()
+		}
+	}()
+	return s
+}
+
+func (s *Session) Send(commandLine string) {
+	s.t.Helper()
+	if _, err := io.WriteString(s.input, commandLine+"\n"); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// Expect skips output lines until one starts with prefix
+func (s *Session) Expect(prefix string) string {
+	s.t.Helper()
+	var timeout = time.After(timeout)
+	for {
+		select {
+		case line, ok := <-s.output:
+			if !ok {
+				s.t.Fatalf("output closed, expected %q", prefix)
+			}
+			if strings.HasPrefix(line, prefix) {
+				return line
+			}
+		case <-timeout:
+			s.t.Fatalf("timeout, expected %q", prefix)
+		}
+	}
+}
+
+// CloseInput signals the end of input to the protocol loop
+func (s *Session) CloseInput() {
+	s.input.Close()
+}
+
+// Wait returns the result of the protocol loop
+func (s *Session) Wait() error {
+	s.t.Helper()
+	select {
+	case err := <-s.done:
+		return err
+	case <-time.After(timeout):
+		s.t.Fatal("timeout waiting for end of session")
+		return nil
+	}
+}
+
+func (s *Session) Quit() {
+	s.t.Helper()
+	s.Send("quit")
+	if err := s.Wait(); err != nil {
+		s.t.Fatal(err)
+	}
+}
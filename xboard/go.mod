@@ -0,0 +1,12 @@
+module github.com/ChizhovVadim/CounterGo/xboard
+
+go 1.15
+
+replace github.com/ChizhovVadim/CounterGo/common => ../common
+
+replace github.com/ChizhovVadim/CounterGo/uci => ../uci
+
+require (
+	github.com/ChizhovVadim/CounterGo/common v0.0.0-00010101000000-000000000000
+	github.com/ChizhovVadim/CounterGo/uci v0.0.0-00010101000000-000000000000
+)
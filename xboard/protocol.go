@@ -0,0 +1,568 @@
+package xboard
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ChizhovVadim/CounterGo/common"
+	"github.com/ChizhovVadim/CounterGo/uci"
+)
+
+// Protocol implements CECP (XBoard/WinBoard protocol version 2).
+// Options named Hash and Threads are controlled by memory and cores commands.
+type Protocol struct {
+	Name         string
+	Version      string
+	Options      []uci.Option
+	Engine       uci.Engine
+	out          io.Writer
+	positions    []common.Position
+	force        bool
+	engineSide   bool
+	post         bool
+	analyze      bool
+	movesPerTC   int
+	baseTime     int
+	increment    int
+	moveTime     int
+	depth        int
+	engineTime   int
+	opponentTime int
+	thinking     bool
+	discard      bool
+	engineOutput chan common.SearchInfo
+	bestMove     common.Move
+	cancel       context.CancelFunc
+}
+
+const (
+	mateScore = 100000
+	// time control until the GUI sends level
+	defaultMovesPerTC = 40
+	defaultBaseTime   = 5 * 60 * 1000
+)
+
+// Run reads commands from r and writes responses to w
+// until it gets "quit" or the end of input. A running search is stopped before return.
+func (xb *Protocol) Run(r io.Reader, w io.Writer) error {
+	xb.out = w
+	if err := xb.newGame(); err != nil {
+		return err
+	}
+	xb.movesPerTC = defaultMovesPerTC
+	xb.baseTime = defaultBaseTime
+	xb.engineTime = defaultBaseTime
+	xb.opponentTime = defaultBaseTime
+
+	var commands = make(chan string)
+	var scanErr error
+
+	go func() {
+		defer close(commands)
+		var scanner = bufio.NewScanner(r)
+		for scanner.Scan() {
+			var commandLine = scanner.Text()
+			commands <- commandLine
+			if commandLine == "quit" {
+				return
+			}
+		}
+		scanErr = scanner.Err()
+	}()
+
+	for {
+		select {
+		case command, ok := <-commands:
+			if !ok || command == "quit" {
+				xb.stopSearch()
+				return scanErr
+			}
+			var err = xb.handleCommand(command)
+			if err != nil {
+				fmt.Fprintf(xb.out, "Error (%v): %v\n", err, command)
+			}
+		case searchInfo, ok := <-xb.engineOutput:
+			xb.onEngineOutput(searchInfo, ok)
+		}
+	}
+}
+
+func (xb *Protocol) onEngineOutput(searchInfo common.SearchInfo, ok bool) {
+	if ok {
+		if searchInfo.CurrMove != common.MoveEmpty {
+			return
+		}
+		if xb.post && !xb.discard {
+			fmt.Fprintln(xb.out, searchInfoToXboard(searchInfo))
+		}
+		if len(searchInfo.MainLine) != 0 {
+			xb.bestMove = searchInfo.MainLine[0]
+		}
+		return
+	}
+	xb.thinking = false
+	xb.engineOutput = nil
+	if xb.discard || xb.analyze {
+		return
+	}
+	var p = &xb.positions[len(xb.positions)-1]
+	var child common.Position
+	if xb.bestMove == common.MoveEmpty || !p.MakeMove(xb.bestMove, &child) {
+		return
+	}
+	xb.positions = append(xb.positions, child)
+	fmt.Fprintf(xb.out, "move %v\n", xb.bestMove)
+	xb.reportResult()
+}
+
+// stopSearch cancels the running search without playing its move
+func (xb *Protocol) stopSearch() {
+	if !xb.thinking {
+		return
+	}
+	xb.discard = true
+	xb.cancel()
+	for xb.thinking {
+		var searchInfo, ok = <-xb.engineOutput
+		xb.onEngineOutput(searchInfo, ok)
+	}
+}
+
+func (xb *Protocol) handleCommand(commandLine string) error {
+	var fields = strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return nil
+	}
+	var commandName = fields[0]
+	fields = fields[1:]
+
+	var h func(fields []string) error
+
+	switch commandName {
+	case "xboard", "accepted", "rejected", "random", "hard", "easy",
+		"computer", "name", "rating", "ics", "draw", "bk", "hint", ".":
+		// nothing to do
+		return nil
+	case "protover":
+		h = xb.protoverCommand
+	case "new":
+		h = xb.newCommand
+	case "variant":
+		h = xb.variantCommand
+	case "force":
+		h = xb.forceCommand
+	case "go":
+		h = xb.goCommand
+	case "playother":
+		h = xb.playOtherCommand
+	case "usermove":
+		h = xb.userMoveCommand
+	case "?":
+		h = xb.moveNowCommand
+	case "ping":
+		h = xb.pingCommand
+	case "level":
+		h = xb.levelCommand
+	case "st":
+		h = xb.stCommand
+	case "sd":
+		h = xb.sdCommand
+	case "time":
+		h = xb.timeCommand
+	case "otim":
+		h = xb.otimCommand
+	case "setboard":
+		h = xb.setBoardCommand
+	case "undo":
+		h = xb.undoCommand
+	case "remove":
+		h = xb.removeCommand
+	case "result":
+		h = xb.resultCommand
+	case "post":
+		h = xb.postCommand
+	case "nopost":
+		h = xb.noPostCommand
+	case "analyze":
+		h = xb.analyzeCommand
+	case "exit":
+		h = xb.exitCommand
+	case "memory":
+		h = xb.memoryCommand
+	case "cores":
+		h = xb.coresCommand
+	}
+
+	if h == nil {
+		// protocol version 1 sends moves without usermove
+		if _, ok := xb.parseMove(commandName); ok {
+			return xb.userMoveCommand([]string{commandName})
+		}
+		return errors.New("unknown command")
+	}
+
+	return h(fields)
+}
+
+func (xb *Protocol) protoverCommand(fields []string) error {
+	fmt.Fprintln(xb.out, "feature done=0")
+	fmt.Fprintf(xb.out, "feature myname=\"%v %v\"\n", xb.Name, xb.Version)
+	fmt.Fprintln(xb.out, "feature setboard=1 usermove=1 time=1 draw=0 sigint=0 sigterm=0"+
+		" reuse=1 analyze=1 colors=0 ping=1 playother=1 variants=\"normal\""+
+		" memory=1 smp=1")
+	fmt.Fprintln(xb.out, "feature done=1")
+	return nil
+}
+
+func (xb *Protocol) newGame() error {
+	var p, err = common.NewPositionFromFEN(common.InitialPositionFen)
+	if err != nil {
+		return err
+	}
+	xb.positions = []common.Position{p}
+	xb.force = false
+	xb.engineSide = false
+	xb.depth = 0
+	return nil
+}
+
+func (xb *Protocol) newCommand(fields []string) error {
+	xb.stopSearch()
+	var err = xb.newGame()
+	if err != nil {
+		return err
+	}
+	xb.Engine.Clear()
+	return xb.restart()
+}
+
+func (xb *Protocol) variantCommand(fields []string) error {
+	if len(fields) == 0 || fields[0] != "normal" {
+		return errors.New("unsupported variant")
+	}
+	return nil
+}
+
+func (xb *Protocol) forceCommand(fields []string) error {
+	xb.stopSearch()
+	xb.force = true
+	return xb.restart()
+}
+
+func (xb *Protocol) goCommand(fields []string) error {
+	xb.stopSearch()
+	xb.force = false
+	xb.engineSide = xb.sideToMove()
+	return xb.restart()
+}
+
+func (xb *Protocol) playOtherCommand(fields []string) error {
+	xb.stopSearch()
+	xb.force = false
+	xb.engineSide = !xb.sideToMove()
+	return xb.restart()
+}
+
+func (xb *Protocol) userMoveCommand(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid usermove arguments")
+	}
+	var child, ok = xb.parseMove(fields[0])
+	if !ok {
+		fmt.Fprintf(xb.out, "Illegal move: %v\n", fields[0])
+		return nil
+	}
+	xb.stopSearch()
+	xb.positions = append(xb.positions, child)
+	return xb.restart()
+}
+
+func (xb *Protocol) parseMove(s string) (common.Position, bool) {
+	var p = &xb.positions[len(xb.positions)-1]
+	if child, ok := p.MakeMoveLAN(s); ok {
+		return child, true
+	}
+	var child common.Position
+	if move := common.ParseMoveSAN(p, s); move != common.MoveEmpty &&
+		p.MakeMove(move, &child) {
+		return child, true
+	}
+	return common.Position{}, false
+}
+
+func (xb *Protocol) moveNowCommand(fields []string) error {
+	if xb.thinking && !xb.analyze {
+		xb.cancel()
+	}
+	return nil
+}
+
+func (xb *Protocol) pingCommand(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid ping arguments")
+	}
+	fmt.Fprintf(xb.out, "pong %v\n", fields[0])
+	return nil
+}
+
+// level MPS BASE INC, where BASE is minutes or minutes:seconds and INC is seconds
+func (xb *Protocol) levelCommand(fields []string) error {
+	if len(fields) != 3 {
+		return errors.New("invalid level arguments")
+	}
+	var mps, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return err
+	}
+	var base int
+	var minSec = strings.SplitN(fields[1], ":", 2)
+	minutes, err := strconv.Atoi(minSec[0])
+	if err != nil {
+		return err
+	}
+	base = minutes * 60 * 1000
+	if len(minSec) == 2 {
+		seconds, err := strconv.Atoi(minSec[1])
+		if err != nil {
+			return err
+		}
+		base += seconds * 1000
+	}
+	inc, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return err
+	}
+	xb.movesPerTC = mps
+	xb.baseTime = base
+	xb.increment = int(inc * 1000)
+	xb.moveTime = 0
+	xb.engineTime = base
+	xb.opponentTime = base
+	return nil
+}
+
+func (xb *Protocol) stCommand(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid st arguments")
+	}
+	var seconds, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return err
+	}
+	xb.moveTime = int(seconds * 1000)
+	return nil
+}
+
+func (xb *Protocol) sdCommand(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid sd arguments")
+	}
+	var depth, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return err
+	}
+	xb.depth = depth
+	return nil
+}
+
+func (xb *Protocol) timeCommand(fields []string) error {
+	var centiseconds, err = parseCentiseconds(fields)
+	if err != nil {
+		return err
+	}
+	xb.engineTime = centiseconds * 10
+	return nil
+}
+
+func (xb *Protocol) otimCommand(fields []string) error {
+	var centiseconds, err = parseCentiseconds(fields)
+	if err != nil {
+		return err
+	}
+	xb.opponentTime = centiseconds * 10
+	return nil
+}
+
+func parseCentiseconds(fields []string) (int, error) {
+	if len(fields) == 0 {
+		return 0, errors.New("invalid time arguments")
+	}
+	return strconv.Atoi(fields[0])
+}
+
+func (xb *Protocol) setBoardCommand(fields []string) error {
+	var p, err = common.NewPositionFromFEN(strings.Join(fields, " "))
+	if err != nil {
+		return err
+	}
+	xb.stopSearch()
+	xb.positions = []common.Position{p}
+	return xb.restart()
+}
+
+func (xb *Protocol) undoCommand(fields []string) error {
+	return xb.takeBack(1)
+}
+
+func (xb *Protocol) removeCommand(fields []string) error {
+	return xb.takeBack(2)
+}
+
+func (xb *Protocol) takeBack(plies int) error {
+	if len(xb.positions) <= plies {
+		return errors.New("no moves to undo")
+	}
+	xb.stopSearch()
+	xb.positions = xb.positions[:len(xb.positions)-plies]
+	return xb.restart()
+}
+
+func (xb *Protocol) resultCommand(fields []string) error {
+	xb.stopSearch()
+	xb.force = true
+	return nil
+}
+
+func (xb *Protocol) postCommand(fields []string) error {
+	xb.post = true
+	return nil
+}
+
+func (xb *Protocol) noPostCommand(fields []string) error {
+	xb.post = false
+	return nil
+}
+
+func (xb *Protocol) analyzeCommand(fields []string) error {
+	xb.stopSearch()
+	xb.analyze = true
+	return xb.restart()
+}
+
+func (xb *Protocol) exitCommand(fields []string) error {
+	if !xb.analyze {
+		return errors.New("not analyzing")
+	}
+	xb.stopSearch()
+	xb.analyze = false
+	return nil
+}
+
+func (xb *Protocol) memoryCommand(fields []string) error {
+	return xb.setOption("Hash", fields)
+}
+
+func (xb *Protocol) coresCommand(fields []string) error {
+	return xb.setOption("Threads", fields)
+}
+
+func (xb *Protocol) setOption(name string, fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid arguments")
+	}
+	for _, option := range xb.Options {
+		if strings.EqualFold(option.UciName(), name) {
+			return option.Set(fields[0])
+		}
+	}
+	return errors.New("unhandled option")
+}
+
+func (xb *Protocol) sideToMove() bool {
+	return xb.positions[len(xb.positions)-1].WhiteMove
+}
+
+// restart starts analysis or thinking on the engine move, if needed
+func (xb *Protocol) restart() error {
+	if xb.analyze {
+		xb.startSearch(common.LimitsType{Infinite: true})
+		return nil
+	}
+	if xb.force || xb.sideToMove() != xb.engineSide {
+		return nil
+	}
+	if xb.reportResult() {
+		return nil
+	}
+	xb.startSearch(xb.limits())
+	return nil
+}
+
+// reportResult tells the GUI about the end of the game, draws by repetition and the 50-move rule are claimed
+func (xb *Protocol) reportResult() bool {
+	var outcome = common.GameOutcome(xb.positions, true)
+	if !outcome.IsOver() {
+		return false
+	}
+	fmt.Fprintf(xb.out, "%v {%v}\n", outcome.Result, outcome)
+	return true
+}
+
+func (xb *Protocol) limits() common.LimitsType {
+	var result = common.LimitsType{Depth: xb.depth}
+	if xb.moveTime > 0 {
+		result.MoveTime = xb.moveTime
+		return result
+	}
+	var ownTime, opponentTime = xb.engineTime, xb.opponentTime
+	if xb.engineSide {
+		result.WhiteTime, result.BlackTime = ownTime, opponentTime
+		result.WhiteIncrement, result.BlackIncrement = xb.increment, xb.increment
+	} else {
+		result.WhiteTime, result.BlackTime = opponentTime, ownTime
+		result.WhiteIncrement, result.BlackIncrement = xb.increment, xb.increment
+	}
+	if xb.movesPerTC > 0 {
+		// games set up from a FEN continue the move count of the position
+		var movesPlayed = xb.positions[len(xb.positions)-1].FullMove - 1
+		result.MovesToGo = xb.movesPerTC - movesPlayed%xb.movesPerTC
+	}
+	return result
+}
+
+func (xb *Protocol) startSearch(limits common.LimitsType) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	xb.thinking = true
+	xb.discard = false
+	xb.bestMove = common.MoveEmpty
+	xb.engineOutput = make(chan common.SearchInfo)
+	xb.cancel = cancel
+	var positions = make([]common.Position, len(xb.positions))
+	copy(positions, xb.positions)
+	go func() {
+		xb.engineOutput <- xb.Engine.Search(ctx, common.SearchParams{
+			Positions: positions,
+			Limits:    limits,
+			Progress: func(si common.SearchInfo) {
+				select {
+				case xb.engineOutput <- si:
+				default:
+				}
+			},
+		})
+		close(xb.engineOutput)
+	}()
+}
+
+// searchInfoToXboard formats thinking output: ply score time nodes pv.
+// Time is in centiseconds, mate scores are reported as 100000 + moves.
+func searchInfoToXboard(si common.SearchInfo) string {
+	var score = si.Score.Centipawns
+	if si.Score.Mate > 0 {
+		score = mateScore + si.Score.Mate
+	} else if si.Score.Mate < 0 {
+		score = -mateScore + si.Score.Mate
+	}
+	var sb = &strings.Builder{}
+	fmt.Fprintf(sb, "%v %v %v %v", si.Depth, score, si.Time/10, si.Nodes)
+	for _, move := range si.MainLine {
+		sb.WriteString(" ")
+		sb.WriteString(move.String())
+	}
+	return sb.String()
+}
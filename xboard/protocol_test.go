@@ -0,0 +1,169 @@
+package xboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChizhovVadim/CounterGo/uci"
+	"github.com/ChizhovVadim/CounterGo/uci/ucitest"
+)
+
+func newTestSession(t *testing.T, engine uci.Engine) *ucitest.Session {
+	var hash, threads = 16, 1
+	var protocol = &Protocol{
+		Name:    "Counter",
+		Version: "test",
+		Engine:  engine,
+		Options: []uci.Option{
+			&uci.IntOption{Name: "Hash", Min: 4, Max: 1 << 16, Value: &hash},
+			&uci.IntOption{Name: "Threads", Min: 1, Max: 4, Value: &threads},
+		},
+	}
+	return ucitest.NewSession(t, protocol.Run)
+}
+
+// ping waits until all previous commands are processed
+func ping(s *ucitest.Session, n string) {
+	s.Send("ping " + n)
+	s.Expect("pong " + n)
+}
+
+func TestProtover(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("xboard")
+	s.Send("protover 2")
+	s.Expect("feature done=0")
+	if line := s.Expect("feature myname"); line != `feature myname="Counter test"` {
+		t.Error(line)
+	}
+	if line := s.Expect("feature "); !strings.Contains(line, "usermove=1") ||
+		!strings.Contains(line, "setboard=1") || !strings.Contains(line, "analyze=1") {
+		t.Error(line)
+	}
+	s.Expect("feature done=1")
+	s.Quit()
+}
+
+func TestPlayGame(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	s.Send("new")
+	s.Send("level 40 5 2")
+	s.Send("post")
+	s.Send("time 30000")
+	s.Send("otim 25000")
+	s.Send("usermove e2e4")
+	if line := s.Expect(""); line != "5 15 25 100 a7a6" {
+		t.Error(line)
+	}
+	if line := s.Expect("move "); line != "move a7a6" {
+		t.Error(line)
+	}
+	var params = engine.LastParams()
+	if len(params.Positions) != 2 {
+		t.Fatal(len(params.Positions))
+	}
+	var limits = params.Limits
+	if limits.BlackTime != 300000 || limits.WhiteTime != 250000 ||
+		limits.BlackIncrement != 2000 || limits.MovesToGo != 40 {
+		t.Error(limits)
+	}
+	s.Send("usermove d2d4")
+	s.Expect("move ")
+	if n := len(engine.LastParams().Positions); n != 4 {
+		t.Error(n)
+	}
+	s.Send("usermove e2e5")
+	s.Expect("Illegal move: e2e5")
+	s.Quit()
+}
+
+func TestMovesToGoAfterSetBoard(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	s.Send("new")
+	s.Send("force")
+	s.Send("setboard 4k3/8/8/8/8/8/4P3/4K3 w - - 0 38")
+	s.Send("level 40 5 2")
+	s.Send("go")
+	s.Expect("move ")
+	if limits := engine.LastParams().Limits; limits.MovesToGo != 3 {
+		t.Error(limits)
+	}
+	s.Quit()
+}
+
+func TestForceUndoAndGo(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	s.Send("new")
+	s.Send("force")
+	s.Send("usermove e2e4")
+	s.Send("usermove e7e5")
+	s.Send("usermove g1f3")
+	s.Send("remove")
+	s.Send("undo")
+	s.Send("usermove d2d4")
+	s.Send("st 2")
+	s.Send("sd 7")
+	s.Send("go")
+	s.Expect("move ")
+	var params = engine.LastParams()
+	if len(params.Positions) != 2 || params.Positions[1].WhiteMove {
+		t.Error(len(params.Positions))
+	}
+	if params.Limits.MoveTime != 2000 || params.Limits.Depth != 7 {
+		t.Error(params.Limits)
+	}
+	s.Send("force")
+	s.Send("usermove c2c4")
+	ping(s, "1")
+	if n := len(engine.LastParams().Positions); n != 2 {
+		t.Error("engine moved in force mode")
+	}
+	s.Quit()
+}
+
+func TestSetBoardAndResult(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("new")
+	s.Send("force")
+	s.Send("setboard 7k/6Q1/6K1/8/8/8/8/8 b - - 0 1")
+	s.Send("go")
+	s.Expect("1-0 {White mates}")
+	s.Send("setboard 8/8/4k3/8/8/2KB4/8/8 w - - 0 1")
+	s.Send("go")
+	s.Expect("1/2-1/2 {Draw by insufficient material}")
+	s.Send("setboard 7k/8/8/8/8/8/8/K7 x")
+	s.Expect("Error")
+	s.Send("result 1-0 {White mates}")
+	ping(s, "2")
+	s.Quit()
+}
+
+func TestAnalyze(t *testing.T) {
+	var engine = &ucitest.Engine{}
+	var s = newTestSession(t, engine)
+	s.Send("new")
+	s.Send("post")
+	s.Send("analyze")
+	s.Expect("5 15 25 100 a2a3")
+	s.Send("usermove e2e4")
+	if line := s.Expect("5 15 25 100 "); line != "5 15 25 100 a7a6" {
+		t.Error(line)
+	}
+	s.Send("undo")
+	s.Expect("5 15 25 100 a2a3")
+	s.Send("exit")
+	ping(s, "3")
+	s.Quit()
+}
+
+func TestMemoryAndCores(t *testing.T) {
+	var s = newTestSession(t, &ucitest.Engine{})
+	s.Send("memory 64")
+	s.Send("cores 2")
+	s.Send("cores 64")
+	s.Expect("Error (argument out of range): cores 64")
+	s.Quit()
+}